@@ -1,57 +0,0 @@
-/*
-Copyright The Kubernetes Authors.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-// Code generated by applyconfiguration-gen. DO NOT EDIT.
-
-package applyconfiguration
-
-import (
-	v1alpha1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
-	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
-	pipelinev1alpha1 "github.com/apoorvajagtap/trackPodCRD/pkg/client/applyconfiguration/pipeline/v1alpha1"
-	trackpodv1 "github.com/apoorvajagtap/trackPodCRD/pkg/client/applyconfiguration/trackpod/v1"
-	schema "k8s.io/apimachinery/pkg/runtime/schema"
-)
-
-// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
-// apply configuration type exists for the given GroupVersionKind.
-func ForKind(kind schema.GroupVersionKind) interface{} {
-	switch kind {
-	// Group=aj.com, Version=v1
-	case v1.SchemeGroupVersion.WithKind("TrackPod"):
-		return &trackpodv1.TrackPodApplyConfiguration{}
-	case v1.SchemeGroupVersion.WithKind("TrackPodSpec"):
-		return &trackpodv1.TrackPodSpecApplyConfiguration{}
-	case v1.SchemeGroupVersion.WithKind("TrackPodStatus"):
-		return &trackpodv1.TrackPodStatusApplyConfiguration{}
-
-		// Group=aj.com, Version=v1alpha1
-	case v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):
-		return &pipelinev1alpha1.PipelineRunApplyConfiguration{}
-	case v1alpha1.SchemeGroupVersion.WithKind("PipelineRunSpec"):
-		return &pipelinev1alpha1.PipelineRunSpecApplyConfiguration{}
-	case v1alpha1.SchemeGroupVersion.WithKind("PipelineRunStatus"):
-		return &pipelinev1alpha1.PipelineRunStatusApplyConfiguration{}
-	case v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):
-		return &pipelinev1alpha1.TaskRunApplyConfiguration{}
-	case v1alpha1.SchemeGroupVersion.WithKind("TaskRunSpec"):
-		return &pipelinev1alpha1.TaskRunSpecApplyConfiguration{}
-	case v1alpha1.SchemeGroupVersion.WithKind("TaskRunStatus"):
-		return &pipelinev1alpha1.TaskRunStatusApplyConfiguration{}
-
-	}
-	return nil
-}
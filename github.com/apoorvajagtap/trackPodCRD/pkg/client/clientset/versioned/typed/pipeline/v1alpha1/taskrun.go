@@ -1,256 +0,0 @@
-/*
-Copyright The Kubernetes Authors.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software
-distributed under the License is distributed on an "AS IS" BASIS,
-WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and
-limitations under the License.
-*/
-
-// Code generated by client-gen. DO NOT EDIT.
-
-package v1alpha1
-
-import (
-	"context"
-	json "encoding/json"
-	"fmt"
-	"time"
-
-	v1alpha1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
-	pipelinev1alpha1 "github.com/apoorvajagtap/trackPodCRD/pkg/client/applyconfiguration/pipeline/v1alpha1"
-	scheme "github.com/apoorvajagtap/trackPodCRD/pkg/client/clientset/versioned/scheme"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	types "k8s.io/apimachinery/pkg/types"
-	watch "k8s.io/apimachinery/pkg/watch"
-	rest "k8s.io/client-go/rest"
-)
-
-// TaskRunsGetter has a method to return a TaskRunInterface.
-// A group's client should implement this interface.
-type TaskRunsGetter interface {
-	TaskRuns(namespace string) TaskRunInterface
-}
-
-// TaskRunInterface has methods to work with TaskRun resources.
-type TaskRunInterface interface {
-	Create(ctx context.Context, taskRun *v1alpha1.TaskRun, opts v1.CreateOptions) (*v1alpha1.TaskRun, error)
-	Update(ctx context.Context, taskRun *v1alpha1.TaskRun, opts v1.UpdateOptions) (*v1alpha1.TaskRun, error)
-	UpdateStatus(ctx context.Context, taskRun *v1alpha1.TaskRun, opts v1.UpdateOptions) (*v1alpha1.TaskRun, error)
-	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
-	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
-	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.TaskRun, error)
-	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.TaskRunList, error)
-	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
-	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TaskRun, err error)
-	Apply(ctx context.Context, taskRun *pipelinev1alpha1.TaskRunApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.TaskRun, err error)
-	ApplyStatus(ctx context.Context, taskRun *pipelinev1alpha1.TaskRunApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.TaskRun, err error)
-	TaskRunExpansion
-}
-
-// taskRuns implements TaskRunInterface
-type taskRuns struct {
-	client rest.Interface
-	ns     string
-}
-
-// newTaskRuns returns a TaskRuns
-func newTaskRuns(c *AjV1alpha1Client, namespace string) *taskRuns {
-	return &taskRuns{
-		client: c.RESTClient(),
-		ns:     namespace,
-	}
-}
-
-// Get takes name of the taskRun, and returns the corresponding taskRun object, and an error if there is any.
-func (c *taskRuns) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.TaskRun, err error) {
-	result = &v1alpha1.TaskRun{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("taskruns").
-		Name(name).
-		VersionedParams(&options, scheme.ParameterCodec).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// List takes label and field selectors, and returns the list of TaskRuns that match those selectors.
-func (c *taskRuns) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.TaskRunList, err error) {
-	var timeout time.Duration
-	if opts.TimeoutSeconds != nil {
-		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
-	}
-	result = &v1alpha1.TaskRunList{}
-	err = c.client.Get().
-		Namespace(c.ns).
-		Resource("taskruns").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Watch returns a watch.Interface that watches the requested taskRuns.
-func (c *taskRuns) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
-	var timeout time.Duration
-	if opts.TimeoutSeconds != nil {
-		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
-	}
-	opts.Watch = true
-	return c.client.Get().
-		Namespace(c.ns).
-		Resource("taskruns").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Watch(ctx)
-}
-
-// Create takes the representation of a taskRun and creates it.  Returns the server's representation of the taskRun, and an error, if there is any.
-func (c *taskRuns) Create(ctx context.Context, taskRun *v1alpha1.TaskRun, opts v1.CreateOptions) (result *v1alpha1.TaskRun, err error) {
-	result = &v1alpha1.TaskRun{}
-	err = c.client.Post().
-		Namespace(c.ns).
-		Resource("taskruns").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(taskRun).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Update takes the representation of a taskRun and updates it. Returns the server's representation of the taskRun, and an error, if there is any.
-func (c *taskRuns) Update(ctx context.Context, taskRun *v1alpha1.TaskRun, opts v1.UpdateOptions) (result *v1alpha1.TaskRun, err error) {
-	result = &v1alpha1.TaskRun{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("taskruns").
-		Name(taskRun.Name).
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(taskRun).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// UpdateStatus was generated because the type contains a Status member.
-// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *taskRuns) UpdateStatus(ctx context.Context, taskRun *v1alpha1.TaskRun, opts v1.UpdateOptions) (result *v1alpha1.TaskRun, err error) {
-	result = &v1alpha1.TaskRun{}
-	err = c.client.Put().
-		Namespace(c.ns).
-		Resource("taskruns").
-		Name(taskRun.Name).
-		SubResource("status").
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(taskRun).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Delete takes name of the taskRun and deletes it. Returns an error if one occurs.
-func (c *taskRuns) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("taskruns").
-		Name(name).
-		Body(&opts).
-		Do(ctx).
-		Error()
-}
-
-// DeleteCollection deletes a collection of objects.
-func (c *taskRuns) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
-	var timeout time.Duration
-	if listOpts.TimeoutSeconds != nil {
-		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
-	}
-	return c.client.Delete().
-		Namespace(c.ns).
-		Resource("taskruns").
-		VersionedParams(&listOpts, scheme.ParameterCodec).
-		Timeout(timeout).
-		Body(&opts).
-		Do(ctx).
-		Error()
-}
-
-// Patch applies the patch and returns the patched taskRun.
-func (c *taskRuns) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TaskRun, err error) {
-	result = &v1alpha1.TaskRun{}
-	err = c.client.Patch(pt).
-		Namespace(c.ns).
-		Resource("taskruns").
-		Name(name).
-		SubResource(subresources...).
-		VersionedParams(&opts, scheme.ParameterCodec).
-		Body(data).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// Apply takes the given apply declarative configuration, applies it and returns the applied taskRun.
-func (c *taskRuns) Apply(ctx context.Context, taskRun *pipelinev1alpha1.TaskRunApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.TaskRun, err error) {
-	if taskRun == nil {
-		return nil, fmt.Errorf("taskRun provided to Apply must not be nil")
-	}
-	patchOpts := opts.ToPatchOptions()
-	data, err := json.Marshal(taskRun)
-	if err != nil {
-		return nil, err
-	}
-	name := taskRun.Name
-	if name == nil {
-		return nil, fmt.Errorf("taskRun.Name must be provided to Apply")
-	}
-	result = &v1alpha1.TaskRun{}
-	err = c.client.Patch(types.ApplyPatchType).
-		Namespace(c.ns).
-		Resource("taskruns").
-		Name(*name).
-		VersionedParams(&patchOpts, scheme.ParameterCodec).
-		Body(data).
-		Do(ctx).
-		Into(result)
-	return
-}
-
-// ApplyStatus was generated because the type contains a Status member.
-// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
-func (c *taskRuns) ApplyStatus(ctx context.Context, taskRun *pipelinev1alpha1.TaskRunApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.TaskRun, err error) {
-	if taskRun == nil {
-		return nil, fmt.Errorf("taskRun provided to Apply must not be nil")
-	}
-	patchOpts := opts.ToPatchOptions()
-	data, err := json.Marshal(taskRun)
-	if err != nil {
-		return nil, err
-	}
-
-	name := taskRun.Name
-	if name == nil {
-		return nil, fmt.Errorf("taskRun.Name must be provided to Apply")
-	}
-
-	result = &v1alpha1.TaskRun{}
-	err = c.client.Patch(types.ApplyPatchType).
-		Namespace(c.ns).
-		Resource("taskruns").
-		Name(*name).
-		SubResource("status").
-		VersionedParams(&patchOpts, scheme.ParameterCodec).
-		Body(data).
-		Do(ctx).
-		Into(result)
-	return
-}
@@ -1,12 +1,16 @@
 package main
 
 import (
-	"context"
 	"flag"
-	"fmt"
+	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
@@ -14,8 +18,9 @@ import (
 
 	klient "github.com/apoorvajagtap/trackPodCRD/pkg/client/clientset/versioned"
 	kInfFac "github.com/apoorvajagtap/trackPodCRD/pkg/client/informers/externalversions"
+	tpodInf "github.com/apoorvajagtap/trackPodCRD/pkg/client/informers/externalversions/trackpod"
 	"github.com/apoorvajagtap/trackPodCRD/pkg/controller/pipelinerun"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/apoorvajagtap/trackPodCRD/pkg/controller/trackpod"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -29,8 +34,23 @@ func main() {
 	} else {
 		kubeconfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
 	}
+	maxRetries := flag.Int("max-retries", 15, "number of times a failing resource is retried, before it's dropped out of the queue")
+	controllers := flag.String("controllers", "trackpod,pipelinerun", "comma separated list of the controllers to run")
+	tpodWorkers := flag.Int("trackpod-workers", 1, "number of workers processing the TrackPods concurrently")
+	prunWorkers := flag.Int("pipelinerun-workers", 1, "number of workers processing the PipelineRuns concurrently")
+	resyncPeriod := flag.Duration("resync-period", 20*time.Minute, "resync period of the informers")
+	namespace := flag.String("namespace", "", "namespace to watch the resources in, all namespaces if empty")
 	flag.Parse()
 
+	enabled := map[string]bool{}
+	for _, name := range strings.Split(*controllers, ",") {
+		name = strings.TrimSpace(name)
+		if name != "trackpod" && name != "pipelinerun" {
+			klog.Fatalf("unknown controller %q, has to be one of trackpod, pipelinerun", name)
+		}
+		enabled[name] = true
+	}
+
 	// Building config from flags might fail inside the pod,
 	// hence adding the code for usage of in-clusterconfig.
 	config, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
@@ -39,38 +59,70 @@ func main() {
 		// uses serviceAccount mounted inside the pod.
 		config, err = rest.InClusterConfig()
 		if err != nil {
-			klog.Errorf("error %s building inclusterconfig", err.Error())
+			klog.Fatalf("error %s building inclusterconfig", err.Error())
 		}
 	}
 
 	// creating the clientset
 	klientset, err := klient.NewForConfig(config)
 	if err != nil {
-		klog.Errorf("getting klient set %s\n", err.Error())
+		klog.Fatalf("getting klient set %s\n", err.Error())
 	}
-	// fmt.Println(klientset)
-
-	// Listing the existing trackpods.
-	tpods, err := klientset.AjV1().TrackPods("").List(context.Background(), metav1.ListOptions{})
-	if err != nil {
-		klog.Errorf("error while listing trackPods %s\n", err.Error())
-	}
-	fmt.Println(tpods)
-	// fmt.Printf("total trackPod sets: %d\n", len(tpods.Items))
 
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		klog.Errorf("getting std client %s\n", err.Error())
+		klog.Fatalf("getting std client %s\n", err.Error())
 	}
 
-	infoFact := kInfFac.NewSharedInformerFactory(klientset, 20*time.Minute)
-	// infoFact :=
-	ch := make(chan struct{})
-	// c := trackpod.NewController(client, klientset, infoFact.Aj().V1().TrackPods())
-	pc := pipelinerun.NewController(client, klientset, infoFact.Aj().V1alpha1().PipelineRuns(), infoFact.Aj().V1alpha1().TaskRuns())
+	// informer factories are shared by the controllers, so that each
+	// resource is only watched once.
+	infoFact := kInfFac.NewSharedInformerFactoryWithOptions(klientset, *resyncPeriod, kInfFac.WithNamespace(*namespace))
+	// informer factory for the K8s resources (pods, revisions, PDBs) managed by the controllers.
+	kubeInfoFact := informers.NewSharedInformerFactoryWithOptions(client, *resyncPeriod, informers.WithNamespace(*namespace))
+	ch := stopCh()
+
+	// informers have to be requested before the factories are started.
+	var runs []func() error
+	if enabled["trackpod"] {
+		// Aj() of the factory is the pipeline group, TrackPods are
+		// reached through the trackpod group sharing the same factory.
+		tpods := tpodInf.New(infoFact, *namespace, nil).V1().TrackPods()
+		c := trackpod.NewController(client, klientset, tpods, kubeInfoFact.Core().V1().Pods(), kubeInfoFact.Apps().V1().ControllerRevisions(), kubeInfoFact.Policy().V1().PodDisruptionBudgets(), *maxRetries)
+		runs = append(runs, func() error { return c.Run(*tpodWorkers, ch) })
+	}
+	if enabled["pipelinerun"] {
+		pc := pipelinerun.NewController(client, klientset, infoFact.Aj().V1alpha1().PipelineRuns(), infoFact.Aj().V1alpha1().TaskRuns(), kubeInfoFact.Core().V1().Pods(), *maxRetries)
+		runs = append(runs, func() error { return pc.Run(*prunWorkers, ch) })
+	}
 
 	infoFact.Start(ch)
-	if err := pc.Run(ch); err != nil {
-		klog.Errorf("error running controller %s\n", err)
+	kubeInfoFact.Start(ch)
+
+	var wg sync.WaitGroup
+	for _, run := range runs {
+		wg.Add(1)
+		go func(run func() error) {
+			defer wg.Done()
+			if err := run(); err != nil {
+				klog.Errorf("error running controller %s\n", err)
+			}
+		}(run)
 	}
+	wg.Wait()
+}
+
+// returns a channel that's closed on SIGINT/SIGTERM, a second signal exits
+// right away.
+func stopCh() <-chan struct{} {
+	ch := make(chan struct{})
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		klog.Info("Received the shutdown signal, stopping the controllers")
+		close(ch)
+		<-sigCh
+		os.Exit(1)
+	}()
+	return ch
 }
@@ -0,0 +1,33 @@
+package v1alpha1
+
+// ConditionSucceeded is the condition type of PipelineRun & TaskRun, it's
+// Unknown while the run is in progress, and True or False once finished.
+const ConditionSucceeded = "Succeeded"
+
+// Reasons of the Succeeded condition
+const (
+	// ReasonRunning is the reason while the run is in progress.
+	ReasonRunning = "Running"
+	// ReasonSucceeded is the reason when all the pods have completed.
+	ReasonSucceeded = "Succeeded"
+	// ReasonFailed is the reason when any of the pods has failed.
+	ReasonFailed = "Failed"
+	// ReasonCancelled is the reason when the run was cancelled.
+	ReasonCancelled = "Cancelled"
+	// ReasonTimeout is the reason while the pods of TaskRun are still
+	// running past the time they were expected to complete in.
+	ReasonTimeout = "Timeout"
+	// ReasonInvalidPipeline is the reason when the tasks of PipelineRun
+	// can't be run, e.g. as their runAfter dependencies form a cycle.
+	ReasonInvalidPipeline = "InvalidPipeline"
+)
+
+// Reasons of a pipeline task that isn't run (yet)
+const (
+	// ReasonPending is the reason while the task waits on the tasks it
+	// runs after.
+	ReasonPending = "Pending"
+	// ReasonSkipped is the reason when the task won't be run, as the
+	// pipeline has failed or was cancelled.
+	ReasonSkipped = "Skipped"
+)
@@ -0,0 +1,105 @@
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ParamType is the type of a param, one of string, array or object.
+type ParamType string
+
+const (
+	ParamTypeString ParamType = "string"
+	ParamTypeArray  ParamType = "array"
+	ParamTypeObject ParamType = "object"
+)
+
+// ParamSpec declares a param of the task, that's referred to as
+// $(params.<name>) by its steps.
+type ParamSpec struct {
+	Name string `json:"name"`
+	// Type of the param, defaults to string.
+	Type        ParamType `json:"type,omitempty"`
+	Description string    `json:"description,omitempty"`
+	// Default is the value of the param, when the run doesn't set one.
+	Default *ParamValue `json:"default,omitempty"`
+}
+
+// Param is the value of a param, set by the run.
+type Param struct {
+	Name  string     `json:"name"`
+	Value ParamValue `json:"value"`
+}
+
+// ParamValue is a string, array or object value as per its Type, it's
+// written as a plain JSON string, array or object.
+type ParamValue struct {
+	Type      ParamType
+	StringVal string
+	ArrayVal  []string
+	ObjectVal map[string]string
+}
+
+// UnmarshalJSON sets the Type of ParamValue as per the JSON value. The
+// schema lets any value through, failing one would fail the list of all the
+// runs, so numbers & bools (in arrays & objects as well) are taken as
+// strings.
+func (v *ParamValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		*v = ParamValue{Type: ParamTypeString}
+		return nil
+	case data[0] == '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		*v = ParamValue{Type: ParamTypeArray, ArrayVal: make([]string, 0, len(items))}
+		for _, item := range items {
+			v.ArrayVal = append(v.ArrayVal, scalarString(item))
+		}
+		return nil
+	case data[0] == '{':
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		*v = ParamValue{Type: ParamTypeObject, ObjectVal: make(map[string]string, len(fields))}
+		for k, field := range fields {
+			v.ObjectVal[k] = scalarString(field)
+		}
+		return nil
+	case json.Valid(data):
+		*v = ParamValue{Type: ParamTypeString, StringVal: scalarString(data)}
+		return nil
+	}
+	return fmt.Errorf("param value has to be a string, array or object, got %s", data)
+}
+
+// returns the JSON string as is, & any other JSON value as its JSON text.
+func scalarString(data json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s
+	}
+	return string(bytes.TrimSpace(data))
+}
+
+// MarshalJSON writes ParamValue as a plain JSON value of its Type.
+func (v ParamValue) MarshalJSON() ([]byte, error) {
+	switch v.Type {
+	case ParamTypeArray:
+		if v.ArrayVal == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(v.ArrayVal)
+	case ParamTypeObject:
+		if v.ObjectVal == nil {
+			return []byte("{}"), nil
+		}
+		return json.Marshal(v.ObjectVal)
+	}
+	return json.Marshal(v.StringVal)
+}
@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParamValueUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    ParamValue
+		wantErr bool
+	}{
+		{name: "string", in: `"hello"`, want: ParamValue{Type: ParamTypeString, StringVal: "hello"}},
+		{name: "null", in: `null`, want: ParamValue{Type: ParamTypeString}},
+		{name: "array", in: `["a", "b"]`, want: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"a", "b"}}},
+		{name: "empty array", in: `[]`, want: ParamValue{Type: ParamTypeArray, ArrayVal: []string{}}},
+		{name: "object", in: `{"k": "v"}`, want: ParamValue{Type: ParamTypeObject, ObjectVal: map[string]string{"k": "v"}}},
+		// numbers & bools are let through by the schema, & taken as strings.
+		{name: "integer", in: `3`, want: ParamValue{Type: ParamTypeString, StringVal: "3"}},
+		{name: "float", in: `-1.5e3`, want: ParamValue{Type: ParamTypeString, StringVal: "-1.5e3"}},
+		{name: "bool", in: `true`, want: ParamValue{Type: ParamTypeString, StringVal: "true"}},
+		{name: "array of scalars", in: `[1, true, "x", null]`, want: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"1", "true", "x", ""}}},
+		{name: "nested array", in: `[[1, 2]]`, want: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"[1, 2]"}}},
+		{name: "object of scalars", in: `{"port": 80, "tls": false}`, want: ParamValue{Type: ParamTypeObject, ObjectVal: map[string]string{"port": "80", "tls": "false"}}},
+		{name: "invalid", in: `nul`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ParamValue
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %+v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) unexpected error: %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParamValueMarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   ParamValue
+		want string
+	}{
+		{in: ParamValue{StringVal: "hello"}, want: `"hello"`},
+		{in: ParamValue{Type: ParamTypeArray}, want: `[]`},
+		{in: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"a"}}, want: `["a"]`},
+		{in: ParamValue{Type: ParamTypeObject}, want: `{}`},
+		{in: ParamValue{Type: ParamTypeObject, ObjectVal: map[string]string{"k": "v"}}, want: `{"k":"v"}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("Marshal(%+v) unexpected error: %v", tt.in, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal(%+v) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
@@ -24,11 +24,70 @@ type PipelineRunList struct {
 }
 
 type PipelineRunSpec struct {
-	Message string `json:"message"`
-	Count   int    `json:"count"`
+	// Tasks are the tasks of the pipeline, run as a DAG of their runAfter
+	// dependencies. A pipeline without tasks runs a single TaskRun of
+	// Message & Count.
+	// +listType=map
+	// +listMapKey=name
+	Tasks []PipelineTask `json:"tasks,omitempty"`
+	// Params are the values passed into the tasks, a task gets the params
+	// it declares. The params of a task can refer to them as well, as
+	// $(params.<name>).
+	Params []Param `json:"params,omitempty"`
+	// Workspaces bind the workspaces of the tasks to volumes, a task gets
+	// the workspaces it declares. A volumeClaimTemplate workspace is shared
+	// by all the tasks of the run.
+	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	Message    string             `json:"message"`
+	Count      int                `json:"count"`
+	// Cancelled stops the run, pods that are yet to complete are deleted.
+	Cancelled bool `json:"cancelled,omitempty"`
+	// DeletionGracePeriodSeconds is the grace period given to the pods
+	// drained on deletion of PipelineRun. Pod's own grace period is used when
+	// it's not set.
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty"`
+}
+
+// PipelineTask is a task of the pipeline, run as a TaskRun of its own.
+type PipelineTask struct {
+	// Name of the task, unique within the pipeline.
+	Name string `json:"name"`
+	// TaskRunSpec is the spec of the TaskRun the task is run with.
+	TaskRunSpec TaskRunSpec `json:"taskRunSpec"`
+	// RunAfter are the names of the tasks that have to succeed before this
+	// task is started. Tasks not depending on each other are run in parallel.
+	RunAfter []string `json:"runAfter,omitempty"`
 }
 
 type PipelineRunStatus struct {
+	// Message is the message of the TaskRun, for a pipeline of a single task.
 	Message string `json:"message"`
-	Count   int    `json:"count"`
+	// Count is the number of pods completed by the TaskRuns of the pipeline.
+	Count int `json:"count"`
+	// Tasks are the states of the pipeline's tasks, in the order they are run.
+	Tasks []PipelineTaskStatus `json:"tasks,omitempty"`
+	// ObservedGeneration is the generation of PipelineRun last observed by
+	// the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// StartTime is the time the run was started at.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the run was finished at.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Conditions are the latest observations of the run's state, see
+	// ConditionSucceeded.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// PipelineTaskStatus is the state of a task of the pipeline.
+type PipelineTaskStatus struct {
+	// Name of the task.
+	Name string `json:"name"`
+	// TaskRunName is the name of the TaskRun the task is run with, empty
+	// until it's started.
+	TaskRunName string `json:"taskRunName,omitempty"`
+	// Reason is the reason of the TaskRun's Succeeded condition, Pending
+	// until the task is started & Skipped if it won't be.
+	Reason string `json:"reason"`
 }
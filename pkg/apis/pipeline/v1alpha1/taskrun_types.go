@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,10 +26,115 @@ type TaskRunList struct {
 
 type TaskRunSpec struct {
 	Message string `json:"message"`
-	Count   int    `json:"count"`
+	// Count is the number of pods run, at least one pod is run for the
+	// steps.
+	Count int `json:"count"`
+	// Steps are run in order inside each pod, a step is only started once
+	// the previous ones have succeeded. The pods echo the Message without
+	// any steps.
+	Steps []Step `json:"steps,omitempty"`
+	// ParamSpecs declare the params of the task, their $(params.<name>)
+	// references in the steps are replaced by the values of Params.
+	ParamSpecs []ParamSpec `json:"paramSpecs,omitempty"`
+	// Params are the values of the declared params, the ones not set take
+	// their defaults.
+	Params []Param `json:"params,omitempty"`
+	// Results declare the results the steps write to, at
+	// $(results.<name>.path). Downstream tasks of the pipeline refer to
+	// them as $(tasks.<task>.results.<name>).
+	Results []ResultSpec `json:"results,omitempty"`
+	// Workspaces declare the volumes mounted into all the steps, at
+	// $(workspaces.<name>.path).
+	Workspaces []WorkspaceDeclaration `json:"workspaces,omitempty"`
+	// WorkspaceBindings are the volumes backing the declared workspaces.
+	WorkspaceBindings []WorkspaceBinding `json:"workspaceBindings,omitempty"`
+	// Cancelled stops the run, pods that are yet to complete are deleted.
+	Cancelled bool `json:"cancelled,omitempty"`
+	// DeletionGracePeriodSeconds is the grace period given to the pods
+	// drained on deletion of TaskRun. Pod's own grace period is used when
+	// it's not set.
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty"`
+}
+
+// Step is a container run by the pods of TaskRun.
+type Step struct {
+	// Name of the step, unique within the task.
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	// Command is the entrypoint of the step, image's entrypoint is used
+	// when it's not set.
+	Command []string `json:"command,omitempty"`
+	// Args are the arguments to the entrypoint, or to the Script.
+	Args       []string        `json:"args,omitempty"`
+	Env        []corev1.EnvVar `json:"env,omitempty"`
+	WorkingDir string          `json:"workingDir,omitempty"`
+	// Script is run by /bin/sh instead of the Command, the Args are passed
+	// on to it as $1, $2...
+	Script string `json:"script,omitempty"`
+}
+
+// ResultSpec declares a result of the task. The results are passed on
+// through the termination message of the pod, that's limited to 4096 bytes:
+// all the results of a task together, as name=<base64 value> lines, can't
+// take more than that (about 3KB of values). TaskRun with larger results
+// fails.
+type ResultSpec struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// WorkspaceDeclaration declares a workspace of the task.
+type WorkspaceDeclaration struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// MountPath is where the workspace is mounted in the steps, defaults
+	// to /workspace/<name>.
+	MountPath string `json:"mountPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+	// Optional workspace doesn't have to be bound, its path is empty then.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// WorkspaceBinding binds a workspace to the volume backing it, exactly one
+// of the volume sources has to be set.
+type WorkspaceBinding struct {
+	Name string `json:"name"`
+	// SubPath is the directory of the volume that's mounted as the
+	// workspace.
+	SubPath string `json:"subPath,omitempty"`
+	// EmptyDir is a scratch volume of each pod, it isn't shared between
+	// the tasks.
+	EmptyDir              *corev1.EmptyDirVolumeSource              `json:"emptyDir,omitempty"`
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	ConfigMap             *corev1.ConfigMapVolumeSource             `json:"configMap,omitempty"`
+	Secret                *corev1.SecretVolumeSource                `json:"secret,omitempty"`
+	// VolumeClaimTemplate is the PVC created for each run of PipelineRun,
+	// it's deleted along with the PipelineRun.
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+}
+
+// TaskRunResult is a result written by the steps of TaskRun.
+type TaskRunResult struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 type TaskRunStatus struct {
 	Message string `json:"message"`
 	Count   int    `json:"count"`
+	// ObservedGeneration is the generation of TaskRun last observed by
+	// the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// StartTime is the time the run was started at.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the run was finished at.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Results are the results written by the steps, once the TaskRun has
+	// succeeded.
+	Results []TaskRunResult `json:"results,omitempty"`
+	// Conditions are the latest observations of the run's state, see
+	// ConditionSucceeded.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
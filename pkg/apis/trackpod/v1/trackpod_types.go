@@ -1,21 +1,199 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type TrackPodSpec struct {
-	Message string `json:"message"`
+	// Message is optional, when set it is injected into every container
+	// of the pod as the MESSAGE environment variable.
+	Message string `json:"message,omitempty"`
 	Count   int    `json:"count"`
+	// Selector is a label query over the pods managed by TrackPod, it has
+	// to match the labels of the pod template. Defaults to the
+	// controller: <name> label, that's set on every pod.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Template describes the pods that are created, Count of them are
+	// stamped out by the controller.
+	Template corev1.PodTemplateSpec `json:"template"`
+	// Strategy is how the pods are replaced, when the pod template or the
+	// message is modified. Defaults to RollingUpdate.
+	Strategy TrackPodStrategy `json:"strategy,omitempty"`
+	// ScaleDownOrder picks the Newest or Oldest pods to be deleted first on
+	// scale down, among the pods ranked equal otherwise. Defaults to Newest.
+	ScaleDownOrder ScaleDownOrder `json:"scaleDownOrder,omitempty"`
+	// ScaleDownPolicy is how the pods are removed on scale down & rollout,
+	// Evict has them evicted so that the PodDisruptionBudgets are respected.
+	// Defaults to Delete.
+	ScaleDownPolicy ScaleDownPolicy `json:"scaleDownPolicy,omitempty"`
+	// MinReadySeconds is the minimum number of seconds a pod has to be
+	// ready for, to be considered available. Defaults to 0.
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+	// ProgressDeadlineSeconds is the maximum number of seconds TrackPod can
+	// take to progress, before it's reported with a Progressing=False
+	// (ProgressDeadlineExceeded) condition. Defaults to 600.
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+	// DisruptionBudget, when set, has a PodDisruptionBudget created for the
+	// pods of TrackPod, limiting the pods evicted at once (e.g. on a node
+	// drain).
+	DisruptionBudget *DisruptionBudget `json:"disruptionBudget,omitempty"`
+	// Paused stops the controller from creating, deleting or replacing the
+	// pods of TrackPod, while its status is still kept up to date.
+	Paused bool `json:"paused,omitempty"`
+	// RevisionHistoryLimit is the number of old ControllerRevisions kept
+	// around to roll back to. Defaults to 10.
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+	// RollbackTo rolls the pod template & message back to the given
+	// revision, it's cleared by the controller once rolled back.
+	RollbackTo *RollbackConfig `json:"rollbackTo,omitempty"`
+	// DeletionGracePeriodSeconds is the grace period given to the pods
+	// drained on deletion of TrackPod. Pod's own grace period is used when
+	// it's not set.
+	DeletionGracePeriodSeconds *int64 `json:"deletionGracePeriodSeconds,omitempty"`
+}
+
+// DisruptionBudget takes either of minAvailable or maxUnavailable, as a
+// number or a percentage of the pods.
+type DisruptionBudget struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+type RollbackConfig struct {
+	// Revision to roll back to, 0 rolls back to the previous revision.
+	Revision int64 `json:"revision,omitempty"`
+}
+
+type ScaleDownOrder string
+
+const (
+	NewestScaleDownOrder ScaleDownOrder = "Newest"
+	OldestScaleDownOrder ScaleDownOrder = "Oldest"
+)
+
+type ScaleDownPolicy string
+
+const (
+	// DeleteScaleDownPolicy deletes the pods right away.
+	DeleteScaleDownPolicy ScaleDownPolicy = "Delete"
+	// EvictScaleDownPolicy removes the pods through the Eviction API, pods
+	// the disruption budgets won't allow to be evicted are retried later.
+	EvictScaleDownPolicy ScaleDownPolicy = "Evict"
+)
+
+// PodDeletionCostAnnotation on a pod ranks it for the scale down, pods with
+// a lower cost are deleted first.
+const PodDeletionCostAnnotation = "controller.kubernetes.io/pod-deletion-cost"
+
+type TrackPodStrategyType string
+
+const (
+	// RecreateTrackPodStrategyType deletes all the old pods, before the new
+	// ones are created.
+	RecreateTrackPodStrategyType TrackPodStrategyType = "Recreate"
+	// RollingUpdateTrackPodStrategyType gradually replaces the old pods with
+	// new ones, bounded by maxSurge & maxUnavailable.
+	RollingUpdateTrackPodStrategyType TrackPodStrategyType = "RollingUpdate"
+)
+
+type TrackPodStrategy struct {
+	// Type is either Recreate or RollingUpdate.
+	Type TrackPodStrategyType `json:"type,omitempty"`
+	// RollingUpdate is only honoured for the RollingUpdate type.
+	RollingUpdate *RollingUpdateTrackPod `json:"rollingUpdate,omitempty"`
+}
+
+type RollingUpdateTrackPod struct {
+	// MaxUnavailable is the number (or percentage of Count) of pods that
+	// can be unavailable during the update. Defaults to 25%.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MaxSurge is the number (or percentage of Count) of pods that can be
+	// created over Count during the update. Defaults to 25%.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
 }
 
 type TrackPodStatus struct {
 	Message string `json:"message"`
 	Count   int    `json:"count"`
+	// ObservedGeneration is the generation of TrackPod last observed by
+	// the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// ReadyReplicas is the number of pods with a Ready condition.
+	ReadyReplicas int `json:"readyReplicas,omitempty"`
+	// AvailableReplicas is the number of pods ready for at least
+	// minReadySeconds.
+	AvailableReplicas int `json:"availableReplicas,omitempty"`
+	// CurrentRevision is the ControllerRevision of the pods, before the
+	// rollout of UpdateRevision is complete.
+	CurrentRevision string `json:"currentRevision,omitempty"`
+	// UpdateRevision is the ControllerRevision of the current pod template.
+	UpdateRevision string `json:"updateRevision,omitempty"`
+	// Selector is the label selector of the pods in string form, it's used
+	// by the scale subresource (e.g. for HorizontalPodAutoscaler).
+	Selector string `json:"selector,omitempty"`
+	// UpdatedReplicas is the number of pods created for the current pod
+	// template, i.e. carrying its pod-template-hash label.
+	UpdatedReplicas int `json:"updatedReplicas,omitempty"`
+	// Phases is the number of pods in each phase.
+	Phases PodPhaseCounts `json:"phases,omitempty"`
+	// Restarts is the total number of container restarts across the pods.
+	Restarts int32 `json:"restarts,omitempty"`
+	// FailureReasons are the reasons (e.g. CrashLoopBackOff, OOMKilled) the
+	// containers of the most recent pods are failing with, at most 10 of them.
+	FailureReasons []PodFailure `json:"failureReasons,omitempty"`
+	// BlockedEvictions are the pods the last sync couldn't evict, as a
+	// PodDisruptionBudget won't allow it.
+	BlockedEvictions []string `json:"blockedEvictions,omitempty"`
+	// Conditions are the latest observations of TrackPod's state, one of
+	// Ready, Progressing or Degraded.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+type PodPhaseCounts struct {
+	Pending   int `json:"pending,omitempty"`
+	Running   int `json:"running,omitempty"`
+	Succeeded int `json:"succeeded,omitempty"`
+	Failed    int `json:"failed,omitempty"`
+	Unknown   int `json:"unknown,omitempty"`
+}
+
+// PodFailure is the reason a container of the pod is failing with.
+type PodFailure struct {
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message,omitempty"`
+}
+
+// Condition types of TrackPod
+const (
+	// TrackPodReady means all the desired pods are created & ready.
+	TrackPodReady = "Ready"
+	// TrackPodProgressing means pods are still being created, deleted,
+	// replaced or are yet to be ready.
+	TrackPodProgressing = "Progressing"
+	// TrackPodDegraded means the controller failed to reconcile TrackPod.
+	TrackPodDegraded = "Degraded"
+	// TrackPodPaused means the pods of TrackPod aren't being reconciled,
+	// as per spec.paused.
+	TrackPodPaused = "Paused"
+	// TrackPodDisruptionBudgetSatisfied means the PodDisruptionBudget of
+	// TrackPod has enough healthy pods, only set with spec.disruptionBudget.
+	TrackPodDisruptionBudgetSatisfied = "DisruptionBudgetSatisfied"
+)
+
+// ProgressDeadlineExceeded is the reason of Progressing=False condition,
+// when TrackPod didn't progress within its progressDeadlineSeconds.
+const ProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
 /*Adding following tag, because we want to generate ClientSet for following type*/
 // +genclient
+// +genclient:method=GetScale,verb=get,subresource=scale,result=k8s.io/api/autoscaling/v1.Scale
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=k8s.io/api/autoscaling/v1.Scale,result=k8s.io/api/autoscaling/v1.Scale
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type TrackPod struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -21,8 +21,13 @@ package v1alpha1
 // PipelineRunSpecApplyConfiguration represents an declarative configuration of the PipelineRunSpec type for use
 // with apply.
 type PipelineRunSpecApplyConfiguration struct {
-	Message *string `json:"message,omitempty"`
-	Count   *int    `json:"count,omitempty"`
+	Tasks                      []PipelineTaskApplyConfiguration     `json:"tasks,omitempty"`
+	Params                     []ParamApplyConfiguration            `json:"params,omitempty"`
+	Workspaces                 []WorkspaceBindingApplyConfiguration `json:"workspaces,omitempty"`
+	Message                    *string                              `json:"message,omitempty"`
+	Count                      *int                                 `json:"count,omitempty"`
+	Cancelled                  *bool                                `json:"cancelled,omitempty"`
+	DeletionGracePeriodSeconds *int64                               `json:"deletionGracePeriodSeconds,omitempty"`
 }
 
 // PipelineRunSpecApplyConfiguration constructs an declarative configuration of the PipelineRunSpec type for use with
@@ -31,6 +36,45 @@ func PipelineRunSpec() *PipelineRunSpecApplyConfiguration {
 	return &PipelineRunSpecApplyConfiguration{}
 }
 
+// WithTasks adds the given value to the Tasks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tasks field.
+func (b *PipelineRunSpecApplyConfiguration) WithTasks(values ...*PipelineTaskApplyConfiguration) *PipelineRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTasks")
+		}
+		b.Tasks = append(b.Tasks, *values[i])
+	}
+	return b
+}
+
+// WithParams adds the given value to the Params field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Params field.
+func (b *PipelineRunSpecApplyConfiguration) WithParams(values ...*ParamApplyConfiguration) *PipelineRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithParams")
+		}
+		b.Params = append(b.Params, *values[i])
+	}
+	return b
+}
+
+// WithWorkspaces adds the given value to the Workspaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Workspaces field.
+func (b *PipelineRunSpecApplyConfiguration) WithWorkspaces(values ...*WorkspaceBindingApplyConfiguration) *PipelineRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithWorkspaces")
+		}
+		b.Workspaces = append(b.Workspaces, *values[i])
+	}
+	return b
+}
+
 // WithMessage sets the Message field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Message field is set to the value of the last call.
@@ -46,3 +90,19 @@ func (b *PipelineRunSpecApplyConfiguration) WithCount(value int) *PipelineRunSpe
 	b.Count = &value
 	return b
 }
+
+// WithCancelled sets the Cancelled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cancelled field is set to the value of the last call.
+func (b *PipelineRunSpecApplyConfiguration) WithCancelled(value bool) *PipelineRunSpecApplyConfiguration {
+	b.Cancelled = &value
+	return b
+}
+
+// WithDeletionGracePeriodSeconds sets the DeletionGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionGracePeriodSeconds field is set to the value of the last call.
+func (b *PipelineRunSpecApplyConfiguration) WithDeletionGracePeriodSeconds(value int64) *PipelineRunSpecApplyConfiguration {
+	b.DeletionGracePeriodSeconds = &value
+	return b
+}
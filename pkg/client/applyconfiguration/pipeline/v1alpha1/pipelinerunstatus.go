@@ -18,11 +18,21 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // PipelineRunStatusApplyConfiguration represents an declarative configuration of the PipelineRunStatus type for use
 // with apply.
 type PipelineRunStatusApplyConfiguration struct {
-	Message *string `json:"message,omitempty"`
-	Count   *int    `json:"count,omitempty"`
+	Message            *string                                `json:"message,omitempty"`
+	Count              *int                                   `json:"count,omitempty"`
+	Tasks              []PipelineTaskStatusApplyConfiguration `json:"tasks,omitempty"`
+	ObservedGeneration *int64                                 `json:"observedGeneration,omitempty"`
+	StartTime          *v1.Time                               `json:"startTime,omitempty"`
+	CompletionTime     *v1.Time                               `json:"completionTime,omitempty"`
+	Conditions         []metav1.ConditionApplyConfiguration   `json:"conditions,omitempty"`
 }
 
 // PipelineRunStatusApplyConfiguration constructs an declarative configuration of the PipelineRunStatus type for use with
@@ -46,3 +56,53 @@ func (b *PipelineRunStatusApplyConfiguration) WithCount(value int) *PipelineRunS
 	b.Count = &value
 	return b
 }
+
+// WithTasks adds the given value to the Tasks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tasks field.
+func (b *PipelineRunStatusApplyConfiguration) WithTasks(values ...*PipelineTaskStatusApplyConfiguration) *PipelineRunStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTasks")
+		}
+		b.Tasks = append(b.Tasks, *values[i])
+	}
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *PipelineRunStatusApplyConfiguration) WithObservedGeneration(value int64) *PipelineRunStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *PipelineRunStatusApplyConfiguration) WithStartTime(value v1.Time) *PipelineRunStatusApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
+
+// WithCompletionTime sets the CompletionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionTime field is set to the value of the last call.
+func (b *PipelineRunStatusApplyConfiguration) WithCompletionTime(value v1.Time) *PipelineRunStatusApplyConfiguration {
+	b.CompletionTime = &value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *PipelineRunStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *PipelineRunStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
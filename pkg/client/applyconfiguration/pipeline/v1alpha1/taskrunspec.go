@@ -21,8 +21,16 @@ package v1alpha1
 // TaskRunSpecApplyConfiguration represents an declarative configuration of the TaskRunSpec type for use
 // with apply.
 type TaskRunSpecApplyConfiguration struct {
-	Message *string `json:"message,omitempty"`
-	Count   *int    `json:"count,omitempty"`
+	Message                    *string                                  `json:"message,omitempty"`
+	Count                      *int                                     `json:"count,omitempty"`
+	Steps                      []StepApplyConfiguration                 `json:"steps,omitempty"`
+	ParamSpecs                 []ParamSpecApplyConfiguration            `json:"paramSpecs,omitempty"`
+	Params                     []ParamApplyConfiguration                `json:"params,omitempty"`
+	Results                    []ResultSpecApplyConfiguration           `json:"results,omitempty"`
+	Workspaces                 []WorkspaceDeclarationApplyConfiguration `json:"workspaces,omitempty"`
+	WorkspaceBindings          []WorkspaceBindingApplyConfiguration     `json:"workspaceBindings,omitempty"`
+	Cancelled                  *bool                                    `json:"cancelled,omitempty"`
+	DeletionGracePeriodSeconds *int64                                   `json:"deletionGracePeriodSeconds,omitempty"`
 }
 
 // TaskRunSpecApplyConfiguration constructs an declarative configuration of the TaskRunSpec type for use with
@@ -46,3 +54,97 @@ func (b *TaskRunSpecApplyConfiguration) WithCount(value int) *TaskRunSpecApplyCo
 	b.Count = &value
 	return b
 }
+
+// WithSteps adds the given value to the Steps field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Steps field.
+func (b *TaskRunSpecApplyConfiguration) WithSteps(values ...*StepApplyConfiguration) *TaskRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSteps")
+		}
+		b.Steps = append(b.Steps, *values[i])
+	}
+	return b
+}
+
+// WithParamSpecs adds the given value to the ParamSpecs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ParamSpecs field.
+func (b *TaskRunSpecApplyConfiguration) WithParamSpecs(values ...*ParamSpecApplyConfiguration) *TaskRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithParamSpecs")
+		}
+		b.ParamSpecs = append(b.ParamSpecs, *values[i])
+	}
+	return b
+}
+
+// WithParams adds the given value to the Params field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Params field.
+func (b *TaskRunSpecApplyConfiguration) WithParams(values ...*ParamApplyConfiguration) *TaskRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithParams")
+		}
+		b.Params = append(b.Params, *values[i])
+	}
+	return b
+}
+
+// WithResults adds the given value to the Results field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Results field.
+func (b *TaskRunSpecApplyConfiguration) WithResults(values ...*ResultSpecApplyConfiguration) *TaskRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResults")
+		}
+		b.Results = append(b.Results, *values[i])
+	}
+	return b
+}
+
+// WithWorkspaces adds the given value to the Workspaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Workspaces field.
+func (b *TaskRunSpecApplyConfiguration) WithWorkspaces(values ...*WorkspaceDeclarationApplyConfiguration) *TaskRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithWorkspaces")
+		}
+		b.Workspaces = append(b.Workspaces, *values[i])
+	}
+	return b
+}
+
+// WithWorkspaceBindings adds the given value to the WorkspaceBindings field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the WorkspaceBindings field.
+func (b *TaskRunSpecApplyConfiguration) WithWorkspaceBindings(values ...*WorkspaceBindingApplyConfiguration) *TaskRunSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithWorkspaceBindings")
+		}
+		b.WorkspaceBindings = append(b.WorkspaceBindings, *values[i])
+	}
+	return b
+}
+
+// WithCancelled sets the Cancelled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cancelled field is set to the value of the last call.
+func (b *TaskRunSpecApplyConfiguration) WithCancelled(value bool) *TaskRunSpecApplyConfiguration {
+	b.Cancelled = &value
+	return b
+}
+
+// WithDeletionGracePeriodSeconds sets the DeletionGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionGracePeriodSeconds field is set to the value of the last call.
+func (b *TaskRunSpecApplyConfiguration) WithDeletionGracePeriodSeconds(value int64) *TaskRunSpecApplyConfiguration {
+	b.DeletionGracePeriodSeconds = &value
+	return b
+}
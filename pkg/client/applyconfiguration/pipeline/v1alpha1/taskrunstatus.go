@@ -18,11 +18,21 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // TaskRunStatusApplyConfiguration represents an declarative configuration of the TaskRunStatus type for use
 // with apply.
 type TaskRunStatusApplyConfiguration struct {
-	Message *string `json:"message,omitempty"`
-	Count   *int    `json:"count,omitempty"`
+	Message            *string                              `json:"message,omitempty"`
+	Count              *int                                 `json:"count,omitempty"`
+	ObservedGeneration *int64                               `json:"observedGeneration,omitempty"`
+	StartTime          *v1.Time                             `json:"startTime,omitempty"`
+	CompletionTime     *v1.Time                             `json:"completionTime,omitempty"`
+	Results            []TaskRunResultApplyConfiguration    `json:"results,omitempty"`
+	Conditions         []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
 
 // TaskRunStatusApplyConfiguration constructs an declarative configuration of the TaskRunStatus type for use with
@@ -46,3 +56,53 @@ func (b *TaskRunStatusApplyConfiguration) WithCount(value int) *TaskRunStatusApp
 	b.Count = &value
 	return b
 }
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *TaskRunStatusApplyConfiguration) WithObservedGeneration(value int64) *TaskRunStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *TaskRunStatusApplyConfiguration) WithStartTime(value v1.Time) *TaskRunStatusApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
+
+// WithCompletionTime sets the CompletionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletionTime field is set to the value of the last call.
+func (b *TaskRunStatusApplyConfiguration) WithCompletionTime(value v1.Time) *TaskRunStatusApplyConfiguration {
+	b.CompletionTime = &value
+	return b
+}
+
+// WithResults adds the given value to the Results field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Results field.
+func (b *TaskRunStatusApplyConfiguration) WithResults(values ...*TaskRunResultApplyConfiguration) *TaskRunStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResults")
+		}
+		b.Results = append(b.Results, *values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *TaskRunStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *TaskRunStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
@@ -18,11 +18,29 @@ limitations under the License.
 
 package v1
 
+import (
+	apistrackpodv1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // TrackPodSpecApplyConfiguration represents an declarative configuration of the TrackPodSpec type for use
 // with apply.
 type TrackPodSpecApplyConfiguration struct {
-	Message *string `json:"message,omitempty"`
-	Count   *int    `json:"count,omitempty"`
+	Message                    *string                                   `json:"message,omitempty"`
+	Count                      *int                                      `json:"count,omitempty"`
+	Selector                   *v1.LabelSelectorApplyConfiguration       `json:"selector,omitempty"`
+	Template                   *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	Strategy                   *TrackPodStrategyApplyConfiguration       `json:"strategy,omitempty"`
+	ScaleDownOrder             *apistrackpodv1.ScaleDownOrder            `json:"scaleDownOrder,omitempty"`
+	ScaleDownPolicy            *apistrackpodv1.ScaleDownPolicy           `json:"scaleDownPolicy,omitempty"`
+	MinReadySeconds            *int32                                    `json:"minReadySeconds,omitempty"`
+	ProgressDeadlineSeconds    *int32                                    `json:"progressDeadlineSeconds,omitempty"`
+	DisruptionBudget           *DisruptionBudgetApplyConfiguration       `json:"disruptionBudget,omitempty"`
+	Paused                     *bool                                     `json:"paused,omitempty"`
+	RevisionHistoryLimit       *int32                                    `json:"revisionHistoryLimit,omitempty"`
+	RollbackTo                 *RollbackConfigApplyConfiguration         `json:"rollbackTo,omitempty"`
+	DeletionGracePeriodSeconds *int64                                    `json:"deletionGracePeriodSeconds,omitempty"`
 }
 
 // TrackPodSpecApplyConfiguration constructs an declarative configuration of the TrackPodSpec type for use with
@@ -46,3 +64,99 @@ func (b *TrackPodSpecApplyConfiguration) WithCount(value int) *TrackPodSpecApply
 	b.Count = &value
 	return b
 }
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithSelector(value *v1.LabelSelectorApplyConfiguration) *TrackPodSpecApplyConfiguration {
+	b.Selector = value
+	return b
+}
+
+// WithTemplate sets the Template field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Template field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithTemplate(value *corev1.PodTemplateSpecApplyConfiguration) *TrackPodSpecApplyConfiguration {
+	b.Template = value
+	return b
+}
+
+// WithStrategy sets the Strategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Strategy field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithStrategy(value *TrackPodStrategyApplyConfiguration) *TrackPodSpecApplyConfiguration {
+	b.Strategy = value
+	return b
+}
+
+// WithScaleDownOrder sets the ScaleDownOrder field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleDownOrder field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithScaleDownOrder(value apistrackpodv1.ScaleDownOrder) *TrackPodSpecApplyConfiguration {
+	b.ScaleDownOrder = &value
+	return b
+}
+
+// WithScaleDownPolicy sets the ScaleDownPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleDownPolicy field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithScaleDownPolicy(value apistrackpodv1.ScaleDownPolicy) *TrackPodSpecApplyConfiguration {
+	b.ScaleDownPolicy = &value
+	return b
+}
+
+// WithMinReadySeconds sets the MinReadySeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReadySeconds field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithMinReadySeconds(value int32) *TrackPodSpecApplyConfiguration {
+	b.MinReadySeconds = &value
+	return b
+}
+
+// WithProgressDeadlineSeconds sets the ProgressDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProgressDeadlineSeconds field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithProgressDeadlineSeconds(value int32) *TrackPodSpecApplyConfiguration {
+	b.ProgressDeadlineSeconds = &value
+	return b
+}
+
+// WithDisruptionBudget sets the DisruptionBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisruptionBudget field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithDisruptionBudget(value *DisruptionBudgetApplyConfiguration) *TrackPodSpecApplyConfiguration {
+	b.DisruptionBudget = value
+	return b
+}
+
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithPaused(value bool) *TrackPodSpecApplyConfiguration {
+	b.Paused = &value
+	return b
+}
+
+// WithRevisionHistoryLimit sets the RevisionHistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RevisionHistoryLimit field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithRevisionHistoryLimit(value int32) *TrackPodSpecApplyConfiguration {
+	b.RevisionHistoryLimit = &value
+	return b
+}
+
+// WithRollbackTo sets the RollbackTo field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RollbackTo field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithRollbackTo(value *RollbackConfigApplyConfiguration) *TrackPodSpecApplyConfiguration {
+	b.RollbackTo = value
+	return b
+}
+
+// WithDeletionGracePeriodSeconds sets the DeletionGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionGracePeriodSeconds field is set to the value of the last call.
+func (b *TrackPodSpecApplyConfiguration) WithDeletionGracePeriodSeconds(value int64) *TrackPodSpecApplyConfiguration {
+	b.DeletionGracePeriodSeconds = &value
+	return b
+}
@@ -18,11 +18,27 @@ limitations under the License.
 
 package v1
 
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // TrackPodStatusApplyConfiguration represents an declarative configuration of the TrackPodStatus type for use
 // with apply.
 type TrackPodStatusApplyConfiguration struct {
-	Message *string `json:"message,omitempty"`
-	Count   *int    `json:"count,omitempty"`
+	Message            *string                              `json:"message,omitempty"`
+	Count              *int                                 `json:"count,omitempty"`
+	ObservedGeneration *int64                               `json:"observedGeneration,omitempty"`
+	ReadyReplicas      *int                                 `json:"readyReplicas,omitempty"`
+	AvailableReplicas  *int                                 `json:"availableReplicas,omitempty"`
+	CurrentRevision    *string                              `json:"currentRevision,omitempty"`
+	UpdateRevision     *string                              `json:"updateRevision,omitempty"`
+	Selector           *string                              `json:"selector,omitempty"`
+	UpdatedReplicas    *int                                 `json:"updatedReplicas,omitempty"`
+	Phases             *PodPhaseCountsApplyConfiguration    `json:"phases,omitempty"`
+	Restarts           *int32                               `json:"restarts,omitempty"`
+	FailureReasons     []PodFailureApplyConfiguration       `json:"failureReasons,omitempty"`
+	BlockedEvictions   []string                             `json:"blockedEvictions,omitempty"`
+	Conditions         []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
 }
 
 // TrackPodStatusApplyConfiguration constructs an declarative configuration of the TrackPodStatus type for use with
@@ -46,3 +62,111 @@ func (b *TrackPodStatusApplyConfiguration) WithCount(value int) *TrackPodStatusA
 	b.Count = &value
 	return b
 }
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithObservedGeneration(value int64) *TrackPodStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithReadyReplicas sets the ReadyReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyReplicas field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithReadyReplicas(value int) *TrackPodStatusApplyConfiguration {
+	b.ReadyReplicas = &value
+	return b
+}
+
+// WithAvailableReplicas sets the AvailableReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AvailableReplicas field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithAvailableReplicas(value int) *TrackPodStatusApplyConfiguration {
+	b.AvailableReplicas = &value
+	return b
+}
+
+// WithCurrentRevision sets the CurrentRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentRevision field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithCurrentRevision(value string) *TrackPodStatusApplyConfiguration {
+	b.CurrentRevision = &value
+	return b
+}
+
+// WithUpdateRevision sets the UpdateRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateRevision field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithUpdateRevision(value string) *TrackPodStatusApplyConfiguration {
+	b.UpdateRevision = &value
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithSelector(value string) *TrackPodStatusApplyConfiguration {
+	b.Selector = &value
+	return b
+}
+
+// WithUpdatedReplicas sets the UpdatedReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdatedReplicas field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithUpdatedReplicas(value int) *TrackPodStatusApplyConfiguration {
+	b.UpdatedReplicas = &value
+	return b
+}
+
+// WithPhases sets the Phases field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phases field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithPhases(value *PodPhaseCountsApplyConfiguration) *TrackPodStatusApplyConfiguration {
+	b.Phases = value
+	return b
+}
+
+// WithRestarts sets the Restarts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Restarts field is set to the value of the last call.
+func (b *TrackPodStatusApplyConfiguration) WithRestarts(value int32) *TrackPodStatusApplyConfiguration {
+	b.Restarts = &value
+	return b
+}
+
+// WithFailureReasons adds the given value to the FailureReasons field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailureReasons field.
+func (b *TrackPodStatusApplyConfiguration) WithFailureReasons(values ...*PodFailureApplyConfiguration) *TrackPodStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFailureReasons")
+		}
+		b.FailureReasons = append(b.FailureReasons, *values[i])
+	}
+	return b
+}
+
+// WithBlockedEvictions adds the given value to the BlockedEvictions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the BlockedEvictions field.
+func (b *TrackPodStatusApplyConfiguration) WithBlockedEvictions(values ...string) *TrackPodStatusApplyConfiguration {
+	for i := range values {
+		b.BlockedEvictions = append(b.BlockedEvictions, values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *TrackPodStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *TrackPodStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
@@ -31,26 +31,56 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=aj.com, Version=v1
+	case v1.SchemeGroupVersion.WithKind("DisruptionBudget"):
+		return &trackpodv1.DisruptionBudgetApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PodFailure"):
+		return &trackpodv1.PodFailureApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PodPhaseCounts"):
+		return &trackpodv1.PodPhaseCountsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RollbackConfig"):
+		return &trackpodv1.RollbackConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RollingUpdateTrackPod"):
+		return &trackpodv1.RollingUpdateTrackPodApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TrackPod"):
 		return &trackpodv1.TrackPodApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TrackPodSpec"):
 		return &trackpodv1.TrackPodSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("TrackPodStatus"):
 		return &trackpodv1.TrackPodStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TrackPodStrategy"):
+		return &trackpodv1.TrackPodStrategyApplyConfiguration{}
 
 		// Group=aj.com, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("Param"):
+		return &pipelinev1alpha1.ParamApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ParamSpec"):
+		return &pipelinev1alpha1.ParamSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):
 		return &pipelinev1alpha1.PipelineRunApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PipelineRunSpec"):
 		return &pipelinev1alpha1.PipelineRunSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PipelineRunStatus"):
 		return &pipelinev1alpha1.PipelineRunStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PipelineTask"):
+		return &pipelinev1alpha1.PipelineTaskApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PipelineTaskStatus"):
+		return &pipelinev1alpha1.PipelineTaskStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResultSpec"):
+		return &pipelinev1alpha1.ResultSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Step"):
+		return &pipelinev1alpha1.StepApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):
 		return &pipelinev1alpha1.TaskRunApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TaskRunResult"):
+		return &pipelinev1alpha1.TaskRunResultApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TaskRunSpec"):
 		return &pipelinev1alpha1.TaskRunSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TaskRunStatus"):
 		return &pipelinev1alpha1.TaskRunStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WorkspaceBinding"):
+		return &pipelinev1alpha1.WorkspaceBindingApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WorkspaceDeclaration"):
+		return &pipelinev1alpha1.WorkspaceDeclarationApplyConfiguration{}
 
 	}
 	return nil
@@ -22,6 +22,7 @@ import (
 	"context"
 
 	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
@@ -139,3 +140,25 @@ func (c *FakeTrackPods) Patch(ctx context.Context, name string, pt types.PatchTy
 	}
 	return obj.(*v1.TrackPod), err
 }
+
+// GetScale takes name of the trackPod, and returns the corresponding scale object, and an error if there is any.
+func (c *FakeTrackPods) GetScale(ctx context.Context, trackPodName string, options metav1.GetOptions) (result *autoscalingv1.Scale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(trackpodsResource, c.ns, "scale", trackPodName), &autoscalingv1.Scale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
+
+// UpdateScale takes the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.
+func (c *FakeTrackPods) UpdateScale(ctx context.Context, trackPodName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (result *autoscalingv1.Scale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(trackpodsResource, "scale", c.ns, scale), &autoscalingv1.Scale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
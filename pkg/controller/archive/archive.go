@@ -0,0 +1,110 @@
+// Package archive stores the final status & pod logs of a resource being
+// deleted into a ConfigMap, that outlives the resource itself.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Annotation has to be set to "true" on the resource, for it to be
+	// archived on deletion.
+	Annotation = "aj.com/archive"
+	// uidAnnotation of the ConfigMap is the UID of the archived resource,
+	// telling it apart from an earlier resource of the same name.
+	uidAnnotation = "aj.com/archive-of-uid"
+
+	// number of log lines kept per container.
+	tailLines = int64(100)
+	// ConfigMaps are limited to 1MiB, logs after maxSize are dropped.
+	maxSize = 900 * 1024
+)
+
+// Enabled returns whether obj has opted in to be archived.
+func Enabled(obj metav1.Object) bool {
+	return obj.GetAnnotations()[Annotation] == "true"
+}
+
+// Name returns the name of the ConfigMap obj of kind is archived into.
+func Name(kind string, obj metav1.Object) string {
+	return fmt.Sprintf("%s-%s-archive", strings.ToLower(kind), obj.GetName())
+}
+
+// Archive writes status & the logs of pods into the archive ConfigMap of obj.
+// An already existing archive of obj is left as is, so it's safe to be called
+// on every reconcile of the deleted resource, while the archive of an earlier
+// resource of the same name is replaced. Returns whether the archive was
+// written by this call.
+func Archive(ctx context.Context, kubeClient kubernetes.Interface, kind string, obj metav1.Object, status interface{}, pods []*corev1.Pod) (bool, error) {
+	name := Name(kind, obj)
+	existing, err := kubeClient.CoreV1().ConfigMaps(obj.GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+	if err == nil && existing.Annotations[uidAnnotation] == string(obj.GetUID()) {
+		return false, nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	found := err == nil
+
+	st, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return false, err
+	}
+	data := map[string]string{
+		"status.json": string(st),
+	}
+	size := len(st)
+	for _, pod := range pods {
+		// init containers (e.g. the steps of a TaskRun) are logged as well,
+		// in the order they are run.
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			logs, err := kubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: container.Name,
+				TailLines: &[]int64{tailLines}[0],
+			}).DoRaw(ctx)
+			if err != nil {
+				// pod might not have started at all, logs are best effort.
+				klog.V(4).Infof("error %s getting logs of pod %s/%s", err.Error(), pod.Name, container.Name)
+				continue
+			}
+			if size+len(logs) > maxSize {
+				klog.Warningf("archive of %s %s is full, logs of pod %s/%s are dropped", kind, obj.GetName(), pod.Name, container.Name)
+				continue
+			}
+			size += len(logs)
+			data[fmt.Sprintf("%s.%s.log", pod.Name, container.Name)] = string(logs)
+		}
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: obj.GetNamespace(),
+			Labels: map[string]string{
+				"archive-of": obj.GetName(),
+			},
+			Annotations: map[string]string{
+				uidAnnotation: string(obj.GetUID()),
+			},
+		},
+		Data: data,
+	}
+	if found {
+		klog.Infof("replacing archive %s of an earlier %s of the same name", name, kind)
+		cm.ResourceVersion = existing.ResourceVersion
+		_, err = kubeClient.CoreV1().ConfigMaps(obj.GetNamespace()).Update(ctx, cm, metav1.UpdateOptions{})
+	} else {
+		_, err = kubeClient.CoreV1().ConfigMaps(obj.GetNamespace()).Create(ctx, cm, metav1.CreateOptions{})
+	}
+	return err == nil, err
+}
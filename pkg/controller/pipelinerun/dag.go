@@ -0,0 +1,129 @@
+package pipelinerun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// defaultTaskName is the task of a pipeline without tasks, its TaskRun keeps
+// the <prun>-trun-<generation> name of the single task pipelines.
+const defaultTaskName = "trun"
+
+// returns the tasks of PipelineRun, a pipeline without tasks runs a single
+// task of its Message & Count.
+func pipelineTasks(prun *v1alpha1.PipelineRun) []v1alpha1.PipelineTask {
+	if len(prun.Spec.Tasks) > 0 {
+		return prun.Spec.Tasks
+	}
+	return []v1alpha1.PipelineTask{{
+		Name: defaultTaskName,
+		TaskRunSpec: v1alpha1.TaskRunSpec{
+			Message: prun.Spec.Message,
+			Count:   prun.Spec.Count,
+		},
+	}}
+}
+
+// sortTasks validates the tasks of a pipeline & orders them so that every
+// task comes after the tasks it runs after, otherwise keeping the order they
+// are declared in. A task referring to the results of another one runs after
+// it as well. Fails on invalid or duplicate names, invalid steps, results or
+// workspaces, unknown runAfter tasks or results & dependency cycles.
+func sortTasks(tasks []v1alpha1.PipelineTask) ([]v1alpha1.PipelineTask, error) {
+	byName := map[string]int{}
+	for i, task := range tasks {
+		if errs := validation.IsDNS1123Label(task.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid task name %q: %s", task.Name, strings.Join(errs, ", "))
+		}
+		if _, ok := byName[task.Name]; ok {
+			return nil, fmt.Errorf("task %q is defined more than once", task.Name)
+		}
+		if err := validateSteps(task.TaskRunSpec.Steps); err != nil {
+			return nil, fmt.Errorf("task %q: %w", task.Name, err)
+		}
+		if err := validateResults(task.TaskRunSpec.Results); err != nil {
+			return nil, fmt.Errorf("task %q: %w", task.Name, err)
+		}
+		if err := validateWorkspaces(task.TaskRunSpec.Workspaces); err != nil {
+			return nil, fmt.Errorf("task %q: %w", task.Name, err)
+		}
+		byName[task.Name] = i
+	}
+	for _, task := range tasks {
+		for _, dep := range task.RunAfter {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("task %q runs after unknown task %q", task.Name, dep)
+			}
+		}
+		for dep, results := range resultRefs(task) {
+			i, ok := byName[dep]
+			if !ok {
+				return nil, fmt.Errorf("task %q refers to the results of unknown task %q", task.Name, dep)
+			}
+			for _, result := range results {
+				if !declaresResult(tasks[i], result) {
+					return nil, fmt.Errorf("task %q refers to result %q, that task %q doesn't declare", task.Name, result, dep)
+				}
+			}
+		}
+	}
+
+	// depth first search, a task met again while its dependencies are
+	// still being visited closes a cycle.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(tasks))
+	sorted := make([]v1alpha1.PipelineTask, 0, len(tasks))
+	var path []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[indexOf(path, tasks[i].Name):], tasks[i].Name)
+			return fmt.Errorf("tasks %s form a cycle", strings.Join(cycle, " -> "))
+		}
+		state[i] = visiting
+		path = append(path, tasks[i].Name)
+		for _, dep := range taskDeps(tasks[i]) {
+			if err := visit(byName[dep]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		sorted = append(sorted, tasks[i])
+		return nil
+	}
+	for i := range tasks {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+func indexOf(list []string, s string) int {
+	for i, item := range list {
+		if item == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func declaresResult(task v1alpha1.PipelineTask, name string) bool {
+	for _, result := range task.TaskRunSpec.Results {
+		if result.Name == name {
+			return true
+		}
+	}
+	return false
+}
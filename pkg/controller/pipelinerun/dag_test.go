@@ -0,0 +1,148 @@
+package pipelinerun
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+)
+
+func task(name string, runAfter ...string) v1alpha1.PipelineTask {
+	return v1alpha1.PipelineTask{Name: name, RunAfter: runAfter}
+}
+
+// task with a step referring to the result of each of refs, as task.result
+func taskReferring(name string, refs ...string) v1alpha1.PipelineTask {
+	t := task(name)
+	step := v1alpha1.Step{Name: "main", Image: "busybox"}
+	for _, ref := range refs {
+		dep, result, _ := strings.Cut(ref, ".")
+		step.Args = append(step.Args, "$(tasks."+dep+".results."+result+")")
+	}
+	t.TaskRunSpec.Steps = []v1alpha1.Step{step}
+	return t
+}
+
+func taskDeclaring(name string, results ...string) v1alpha1.PipelineTask {
+	t := task(name)
+	for _, result := range results {
+		t.TaskRunSpec.Results = append(t.TaskRunSpec.Results, v1alpha1.ResultSpec{Name: result})
+	}
+	return t
+}
+
+func TestSortTasks(t *testing.T) {
+	tests := []struct {
+		name    string
+		tasks   []v1alpha1.PipelineTask
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "independent tasks keep their order",
+			tasks: []v1alpha1.PipelineTask{task("c"), task("a"), task("b")},
+			want:  []string{"c", "a", "b"},
+		},
+		{
+			name:  "task comes after the tasks it runs after",
+			tasks: []v1alpha1.PipelineTask{task("a", "b"), task("b")},
+			want:  []string{"b", "a"},
+		},
+		{
+			name:  "diamond",
+			tasks: []v1alpha1.PipelineTask{task("d", "b", "c"), task("b", "a"), task("c", "a"), task("a")},
+			want:  []string{"a", "b", "c", "d"},
+		},
+		{
+			name:  "chain declared backwards",
+			tasks: []v1alpha1.PipelineTask{task("c", "b"), task("b", "a"), task("a")},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "referring to a result runs after its task",
+			tasks: []v1alpha1.PipelineTask{taskReferring("use", "make.out"), taskDeclaring("make", "out")},
+			want:  []string{"make", "use"},
+		},
+		{
+			name:    "task running after itself",
+			tasks:   []v1alpha1.PipelineTask{task("a", "a")},
+			wantErr: "tasks a -> a form a cycle",
+		},
+		{
+			name:    "cycle of three tasks",
+			tasks:   []v1alpha1.PipelineTask{task("x"), task("a", "c"), task("b", "a"), task("c", "b")},
+			wantErr: "tasks a -> c -> b -> a form a cycle",
+		},
+		{
+			name:    "cycle through a result",
+			tasks:   []v1alpha1.PipelineTask{{Name: "a", RunAfter: []string{"b"}, TaskRunSpec: v1alpha1.TaskRunSpec{Results: []v1alpha1.ResultSpec{{Name: "out"}}}}, taskReferring("b", "a.out")},
+			wantErr: "form a cycle",
+		},
+		{
+			name:    "unknown runAfter task",
+			tasks:   []v1alpha1.PipelineTask{task("a", "missing")},
+			wantErr: `task "a" runs after unknown task "missing"`,
+		},
+		{
+			name:    "duplicate task",
+			tasks:   []v1alpha1.PipelineTask{task("a"), task("a")},
+			wantErr: `task "a" is defined more than once`,
+		},
+		{
+			name:    "invalid task name",
+			tasks:   []v1alpha1.PipelineTask{task("Not_A_Label")},
+			wantErr: `invalid task name "Not_A_Label"`,
+		},
+		{
+			name:    "result of unknown task",
+			tasks:   []v1alpha1.PipelineTask{taskReferring("use", "missing.out")},
+			wantErr: `task "use" refers to the results of unknown task "missing"`,
+		},
+		{
+			name:    "result that isn't declared",
+			tasks:   []v1alpha1.PipelineTask{taskReferring("use", "make.other"), taskDeclaring("make", "out")},
+			wantErr: `task "use" refers to result "other", that task "make" doesn't declare`,
+		},
+		{
+			name:  "no tasks",
+			tasks: nil,
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := sortTasks(tt.tasks)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("sortTasks() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sortTasks() unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, task := range sorted {
+				got = append(got, task.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipelineTasks(t *testing.T) {
+	prun := &v1alpha1.PipelineRun{Spec: v1alpha1.PipelineRunSpec{Message: "hi", Count: 2}}
+	tasks := pipelineTasks(prun)
+	if len(tasks) != 1 || tasks[0].Name != defaultTaskName || tasks[0].TaskRunSpec.Message != "hi" || tasks[0].TaskRunSpec.Count != 2 {
+		t.Errorf("pipelineTasks() of a pipeline without tasks = %+v, want the single %q task", tasks, defaultTaskName)
+	}
+
+	prun.Spec.Tasks = []v1alpha1.PipelineTask{task("a"), task("b")}
+	if tasks := pipelineTasks(prun); !reflect.DeepEqual(tasks, prun.Spec.Tasks) {
+		t.Errorf("pipelineTasks() = %+v, want the tasks of spec", tasks)
+	}
+}
@@ -0,0 +1,188 @@
+package pipelinerun
+
+import (
+	"context"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	"github.com/apoorvajagtap/trackPodCRD/pkg/controller/archive"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+// finalizerName holds the deletion of PipelineRun & TaskRun, until their
+// taskruns & pods are cleaned up.
+const finalizerName = "aj.com/pipeline-cleanup"
+
+func hasFinalizer(obj metav1.Object) bool {
+	for _, f := range obj.GetFinalizers() {
+		if f == finalizerName {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	var out []string
+	for _, item := range list {
+		if item != s {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// adds the finalizer to PipelineRun, the update event gets it reconciled
+// again.
+func (c *Controller) addFinalizer(prun *v1alpha1.PipelineRun) error {
+	p := prun.DeepCopy()
+	p.Finalizers = append(p.Finalizers, finalizerName)
+	_, err := c.prunClient.AjV1alpha1().PipelineRuns(p.Namespace).Update(context.Background(), p, metav1.UpdateOptions{})
+	return err
+}
+
+// finalizePipelineRun cleans up after a deleted PipelineRun: archives it (if
+// asked to), deletes its taskruns & removes the finalizer once all of them
+// are gone. Returns whether the taskruns are still being cleaned up.
+func (c *Controller) finalizePipelineRun(prun *v1alpha1.PipelineRun) (bool, error) {
+	if !hasFinalizer(prun) {
+		return false, nil
+	}
+
+	if archive.Enabled(prun) {
+		created, err := archive.Archive(context.Background(), c.kubeClient, "PipelineRun", prun, prun.Status, nil)
+		if err != nil {
+			return false, err
+		}
+		if created {
+			c.recorder.Eventf(prun, corev1.EventTypeNormal, Archived, "Archived status into ConfigMap %s", archive.Name("PipelineRun", prun))
+		}
+	}
+
+	pending, err := c.finalizeTaskRuns(prun, true)
+	if err != nil || pending {
+		return pending, err
+	}
+
+	p := prun.DeepCopy()
+	p.Finalizers = removeString(p.Finalizers, finalizerName)
+	if _, err := c.prunClient.AjV1alpha1().PipelineRuns(p.Namespace).Update(context.Background(), p, metav1.UpdateOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	klog.Infof("PipelineRun %s is finalized", prun.Name)
+	c.recorder.Event(prun, corev1.EventTypeNormal, Finalized, "All taskruns are cleaned up, PipelineRun can be deleted")
+
+	return false, nil
+}
+
+// finalizeTaskRuns finalizes the taskruns of PipelineRun that are being
+// deleted, when deleteAll is set the remaining ones are deleted as well.
+// Returns whether any of the taskruns are still around.
+func (c *Controller) finalizeTaskRuns(prun *v1alpha1.PipelineRun, deleteAll bool) (bool, error) {
+	truns, err := c.trunLister.TaskRuns(prun.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+
+	pending := false
+	for _, trun := range truns {
+		if !metav1.IsControlledBy(trun, prun) {
+			continue
+		}
+		if trun.DeletionTimestamp.IsZero() {
+			if !deleteAll {
+				continue
+			}
+			err := c.prunClient.AjV1alpha1().TaskRuns(trun.Namespace).Delete(context.Background(), trun.Name, metav1.DeleteOptions{})
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				c.recorder.Eventf(prun, corev1.EventTypeWarning, FailedDelete, "Error deleting TaskRun %s: %v", trun.Name, err)
+				return false, err
+			}
+			c.recorder.Eventf(prun, corev1.EventTypeNormal, SuccessfulDelete, "Deleted TaskRun: %s", trun.Name)
+			pending = true
+			continue
+		}
+
+		// taskrun delete event gets the PipelineRun reconciled again.
+		pending = true
+		if _, err := c.finalizeTaskRun(trun); err != nil {
+			return false, err
+		}
+	}
+
+	return pending, nil
+}
+
+// finalizeTaskRun archives the TaskRun (if asked to), drains its pods with
+// the configured grace period & removes the finalizer once all of them are
+// gone. Returns whether pods are still draining.
+func (c *Controller) finalizeTaskRun(trun *v1alpha1.TaskRun) (bool, error) {
+	if !hasFinalizer(trun) {
+		return false, nil
+	}
+
+	pList, err := c.listPods(trun)
+	if err != nil {
+		return false, err
+	}
+
+	// logs are archived before the pods are drained, they are gone along
+	// with the pods afterwards.
+	if archive.Enabled(trun) {
+		created, err := archive.Archive(context.Background(), c.kubeClient, "TaskRun", trun, trun.Status, pList)
+		if err != nil {
+			return false, err
+		}
+		if created {
+			c.recorder.Eventf(trun, corev1.EventTypeNormal, Archived, "Archived status & logs into ConfigMap %s", archive.Name("TaskRun", trun))
+		}
+	}
+
+	deleted := 0
+	for _, pod := range pList {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		err := c.kubeClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{
+			GracePeriodSeconds: trun.Spec.DeletionGracePeriodSeconds,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			c.recorder.Eventf(trun, corev1.EventTypeWarning, FailedDelete, "Error deleting pod %s: %v", pod.Name, err)
+			return false, err
+		}
+		c.recorder.Eventf(trun, corev1.EventTypeNormal, SuccessfulDelete, "Deleted pod: %s", pod.Name)
+		deleted++
+	}
+	if deleted > 0 {
+		c.recorder.Eventf(trun, corev1.EventTypeNormal, Draining, "Waiting for %d pods to terminate", len(pList))
+	}
+	if len(pList) > 0 {
+		// pod delete events get the owning PipelineRun reconciled again.
+		return true, nil
+	}
+
+	t := trun.DeepCopy()
+	t.Finalizers = removeString(t.Finalizers, finalizerName)
+	if _, err := c.prunClient.AjV1alpha1().TaskRuns(t.Namespace).Update(context.Background(), t, metav1.UpdateOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	klog.Infof("TaskRun %s is finalized", trun.Name)
+	c.recorder.Event(trun, corev1.EventTypeNormal, Finalized, "All pods are drained, TaskRun can be deleted")
+
+	return false, nil
+}
@@ -0,0 +1,264 @@
+package pipelinerun
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+)
+
+var (
+	// valid name of a param
+	paramName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+	// $(params.<name>), $(params.<name>[<index>|*]) or $(params.<name>.<key>)
+	paramRef = regexp.MustCompile(`\$\(params\.([a-zA-Z_][a-zA-Z0-9_-]*)(\[(\*|[0-9]+)\]|\.([a-zA-Z0-9_-]+))?\)`)
+)
+
+// replaces the param references in s, with the string values they refer to.
+// Arrays & objects have to be referred to by an index or a key, unknown
+// params aren't allowed.
+func substitute(s string, params map[string]v1alpha1.ParamValue) (string, error) {
+	var err error
+	out := paramRef.ReplaceAllStringFunc(s, func(ref string) string {
+		m := paramRef.FindStringSubmatch(ref)
+		name, index, key := m[1], m[3], m[4]
+		value, ok := params[name]
+		if !ok {
+			err = fmt.Errorf("unknown param %q is referred to in %q", name, s)
+			return ref
+		}
+		switch {
+		case value.Type == v1alpha1.ParamTypeArray && index != "" && index != "*":
+			i, _ := strconv.Atoi(index)
+			if i >= len(value.ArrayVal) {
+				err = fmt.Errorf("index %d of array param %q is out of range", i, name)
+				return ref
+			}
+			return value.ArrayVal[i]
+		case value.Type == v1alpha1.ParamTypeArray:
+			err = fmt.Errorf("array param %q can only be used as a whole element of a list, as $(params.%s[*])", name, name)
+		case value.Type == v1alpha1.ParamTypeObject && key != "":
+			v, ok := value.ObjectVal[key]
+			if !ok {
+				err = fmt.Errorf("object param %q has no key %q", name, key)
+				return ref
+			}
+			return v
+		case value.Type == v1alpha1.ParamTypeObject:
+			err = fmt.Errorf("object param %q has to be referred to by a key, as $(params.%s.<key>)", name, name)
+		case index != "" || key != "":
+			err = fmt.Errorf("string param %q can't be referred to by an index or a key", name)
+		default:
+			return value.StringVal
+		}
+		return ref
+	})
+	return out, err
+}
+
+// replaces the param references in the items of list, an item that's a
+// whole $(params.<name>[*]) reference is expanded into the array elements.
+func substituteList(list []string, params map[string]v1alpha1.ParamValue) ([]string, error) {
+	var out []string
+	for _, item := range list {
+		if m := paramRef.FindStringSubmatch(item); m != nil && m[0] == item && m[3] == "*" {
+			if value, ok := params[m[1]]; ok && value.Type == v1alpha1.ParamTypeArray {
+				out = append(out, value.ArrayVal...)
+				continue
+			}
+		}
+		s, err := substitute(item, params)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// replaces the param references in the value, a string that's a whole
+// $(params.<name>) reference takes the value of the param as is, whatever
+// its type.
+func substituteValue(value v1alpha1.ParamValue, params map[string]v1alpha1.ParamValue) (v1alpha1.ParamValue, error) {
+	var err error
+	switch value.Type {
+	case v1alpha1.ParamTypeArray:
+		value.ArrayVal, err = substituteList(value.ArrayVal, params)
+	case v1alpha1.ParamTypeObject:
+		object := map[string]string{}
+		for k, v := range value.ObjectVal {
+			if object[k], err = substitute(v, params); err != nil {
+				break
+			}
+		}
+		value.ObjectVal = object
+	default:
+		if m := paramRef.FindStringSubmatch(value.StringVal); m != nil && m[0] == value.StringVal && m[2] == "" {
+			if v, ok := params[m[1]]; ok {
+				return *v.DeepCopy(), nil
+			}
+		}
+		value.StringVal, err = substitute(value.StringVal, params)
+	}
+	return value, err
+}
+
+// returns the type of the param, string when it isn't set.
+func paramType(spec v1alpha1.ParamSpec) v1alpha1.ParamType {
+	if spec.Type == "" {
+		return v1alpha1.ParamTypeString
+	}
+	return spec.Type
+}
+
+// returns the type of the value, string when it isn't set.
+func valueType(value v1alpha1.ParamValue) v1alpha1.ParamType {
+	if value.Type == "" {
+		return v1alpha1.ParamTypeString
+	}
+	return value.Type
+}
+
+// returns the values of the params declared by the task, by their names.
+// Fails on invalid declarations, params that aren't declared, values of the
+// wrong type & declared params without any value.
+func taskParams(spec *v1alpha1.TaskRunSpec) (map[string]v1alpha1.ParamValue, error) {
+	declared := map[string]v1alpha1.ParamSpec{}
+	for _, ps := range spec.ParamSpecs {
+		if !paramName.MatchString(ps.Name) {
+			return nil, fmt.Errorf("invalid param name %q", ps.Name)
+		}
+		if _, ok := declared[ps.Name]; ok {
+			return nil, fmt.Errorf("param %q is declared more than once", ps.Name)
+		}
+		switch paramType(ps) {
+		case v1alpha1.ParamTypeString, v1alpha1.ParamTypeArray, v1alpha1.ParamTypeObject:
+		default:
+			return nil, fmt.Errorf("param %q has an invalid type %q", ps.Name, ps.Type)
+		}
+		if ps.Default != nil && valueType(*ps.Default) != paramType(ps) {
+			return nil, fmt.Errorf("default of %s param %q is of type %s", paramType(ps), ps.Name, valueType(*ps.Default))
+		}
+		declared[ps.Name] = ps
+	}
+
+	values := map[string]v1alpha1.ParamValue{}
+	for _, p := range spec.Params {
+		ps, ok := declared[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("param %q isn't declared by the task", p.Name)
+		}
+		if valueType(p.Value) != paramType(ps) {
+			return nil, fmt.Errorf("value of %s param %q is of type %s", paramType(ps), p.Name, valueType(p.Value))
+		}
+		values[p.Name] = p.Value
+	}
+	for _, ps := range spec.ParamSpecs {
+		if _, ok := values[ps.Name]; ok {
+			continue
+		}
+		if ps.Default == nil {
+			return nil, fmt.Errorf("param %q has no value", ps.Name)
+		}
+		values[ps.Name] = *ps.Default
+	}
+	return values, nil
+}
+
+// returns the steps of the task, with the param, result path & workspace
+// path references replaced by the values of its params & the paths of its
+// results & workspaces.
+func resolveSteps(spec *v1alpha1.TaskRunSpec) ([]v1alpha1.Step, error) {
+	params, err := taskParams(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]v1alpha1.Step, 0, len(spec.Steps))
+	for _, step := range spec.Steps {
+		s := *step.DeepCopy()
+		if s.Image, err = substitute(s.Image, params); err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		if s.Command, err = substituteList(s.Command, params); err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		if s.Args, err = substituteList(s.Args, params); err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		for i := range s.Env {
+			if s.Env[i].Value, err = substitute(s.Env[i].Value, params); err != nil {
+				return nil, fmt.Errorf("step %q: %w", step.Name, err)
+			}
+		}
+		if s.WorkingDir, err = substitute(s.WorkingDir, params); err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		if s.Script, err = substitute(s.Script, params); err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		steps = append(steps, s)
+	}
+
+	// paths of the declared results & workspaces are known to the steps as
+	// $(results.<name>.path) & $(workspaces.<name>.path).
+	resolved := v1alpha1.TaskRunSpec{Steps: steps}
+	err = mapTaskStrings(&resolved, func(s string) (string, error) {
+		s, err := substituteResultPaths(s, spec.Results)
+		if err != nil {
+			return s, err
+		}
+		return substituteWorkspacePaths(s, spec)
+	})
+	return resolved.Steps, err
+}
+
+// returns the params of PipelineRun, by their names.
+func pipelineParams(prun *v1alpha1.PipelineRun) (map[string]v1alpha1.ParamValue, error) {
+	params := map[string]v1alpha1.ParamValue{}
+	for _, p := range prun.Spec.Params {
+		if !paramName.MatchString(p.Name) {
+			return nil, fmt.Errorf("invalid param name %q", p.Name)
+		}
+		if _, ok := params[p.Name]; ok {
+			return nil, fmt.Errorf("param %q is set more than once", p.Name)
+		}
+		params[p.Name] = p.Value
+	}
+	return params, nil
+}
+
+// resolveParams passes the params of PipelineRun into its tasks: the
+// references in the params of a task are replaced, & the params it declares
+// without setting are taken from PipelineRun. The steps of the resolved
+// tasks are checked for their param references, so that an invalid task
+// fails the pipeline before any of its pods are created.
+func resolveParams(prun *v1alpha1.PipelineRun, tasks []v1alpha1.PipelineTask) ([]v1alpha1.PipelineTask, error) {
+	params, err := pipelineParams(prun)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]v1alpha1.PipelineTask, 0, len(tasks))
+	for _, task := range tasks {
+		t := *task.DeepCopy()
+		set := map[string]bool{}
+		for i, p := range t.TaskRunSpec.Params {
+			if t.TaskRunSpec.Params[i].Value, err = substituteValue(p.Value, params); err != nil {
+				return nil, fmt.Errorf("task %q: param %q: %w", task.Name, p.Name, err)
+			}
+			set[p.Name] = true
+		}
+		for _, ps := range t.TaskRunSpec.ParamSpecs {
+			if value, ok := params[ps.Name]; ok && !set[ps.Name] {
+				t.TaskRunSpec.Params = append(t.TaskRunSpec.Params, v1alpha1.Param{Name: ps.Name, Value: *value.DeepCopy()})
+			}
+		}
+		if _, err := resolveSteps(&t.TaskRunSpec); err != nil {
+			return nil, fmt.Errorf("task %q: %w", task.Name, err)
+		}
+		resolved = append(resolved, t)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,291 @@
+package pipelinerun
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func str(s string) v1alpha1.ParamValue {
+	return v1alpha1.ParamValue{Type: v1alpha1.ParamTypeString, StringVal: s}
+}
+
+func arr(items ...string) v1alpha1.ParamValue {
+	return v1alpha1.ParamValue{Type: v1alpha1.ParamTypeArray, ArrayVal: items}
+}
+
+func obj(kv map[string]string) v1alpha1.ParamValue {
+	return v1alpha1.ParamValue{Type: v1alpha1.ParamTypeObject, ObjectVal: kv}
+}
+
+var testParams = map[string]v1alpha1.ParamValue{
+	"name":   str("world"),
+	"ref":    str("$(params.name)"),
+	"list":   arr("a", "b", "c"),
+	"empty":  arr(),
+	"config": obj(map[string]string{"host": "example.com", "port": "80"}),
+}
+
+func TestSubstitute(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr string
+	}{
+		{name: "no references", in: "echo hello", want: "echo hello"},
+		{name: "string", in: "hello $(params.name)!", want: "hello world!"},
+		{name: "repeated", in: "$(params.name)-$(params.name)", want: "world-world"},
+		{name: "array element", in: "$(params.list[1])", want: "b"},
+		{name: "array element out of range", in: "$(params.list[3])", wantErr: `index 3 of array param "list" is out of range`},
+		{name: "array element of empty array", in: "$(params.empty[0])", wantErr: `index 0 of array param "empty" is out of range`},
+		{name: "whole array", in: "$(params.list)", wantErr: `array param "list" can only be used as a whole element of a list`},
+		{name: "array expansion inside a string", in: "x $(params.list[*])", wantErr: `array param "list" can only be used as a whole element of a list`},
+		{name: "object key", in: "$(params.config.host):$(params.config.port)", want: "example.com:80"},
+		{name: "missing object key", in: "$(params.config.user)", wantErr: `object param "config" has no key "user"`},
+		{name: "whole object", in: "$(params.config)", wantErr: `object param "config" has to be referred to by a key`},
+		{name: "string by index", in: "$(params.name[0])", wantErr: `string param "name" can't be referred to by an index or a key`},
+		{name: "string by key", in: "$(params.name.key)", wantErr: `string param "name" can't be referred to by an index or a key`},
+		{name: "unknown param", in: "$(params.missing)", wantErr: `unknown param "missing"`},
+		// values are substituted once, references in them are kept as is.
+		{name: "reference in the value", in: "$(params.ref)", want: "$(params.name)"},
+		{name: "nested reference", in: "$(params.$(params.name))", want: "$(params.world)"},
+		{name: "not a reference", in: "$(params.)", want: "$(params.)"},
+		{name: "other references", in: "$(results.out.path) $(tasks.a.results.b)", want: "$(results.out.path) $(tasks.a.results.b)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := substitute(tt.in, testParams)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("substitute(%q) error = %v, want it to contain %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("substitute(%q) unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("substitute(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstituteList(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    []string
+		wantErr string
+	}{
+		{name: "nil", in: nil, want: nil},
+		{name: "strings", in: []string{"-n", "$(params.name)"}, want: []string{"-n", "world"}},
+		{name: "array expansion", in: []string{"first", "$(params.list[*])", "last"}, want: []string{"first", "a", "b", "c", "last"}},
+		{name: "empty array expansion", in: []string{"first", "$(params.empty[*])"}, want: []string{"first"}},
+		{name: "array expanded twice", in: []string{"$(params.list[*])", "$(params.list[*])"}, want: []string{"a", "b", "c", "a", "b", "c"}},
+		{name: "array element", in: []string{"$(params.list[2])"}, want: []string{"c"}},
+		{name: "expansion of unknown param", in: []string{"$(params.missing[*])"}, wantErr: `unknown param "missing"`},
+		{name: "expansion of string param", in: []string{"$(params.name[*])"}, wantErr: `string param "name" can't be referred to by an index or a key`},
+		{name: "expansion inside an item", in: []string{"--items=$(params.list[*])"}, wantErr: `array param "list" can only be used as a whole element of a list`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := substituteList(tt.in, testParams)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("substituteList(%q) error = %v, want it to contain %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("substituteList(%q) unexpected error: %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("substituteList(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubstituteValue(t *testing.T) {
+	tests := []struct {
+		name string
+		in   v1alpha1.ParamValue
+		want v1alpha1.ParamValue
+	}{
+		{name: "string", in: str("hi $(params.name)"), want: str("hi world")},
+		{name: "whole reference takes an array as is", in: str("$(params.list)"), want: arr("a", "b", "c")},
+		{name: "whole reference takes an object as is", in: str("$(params.config)"), want: obj(map[string]string{"host": "example.com", "port": "80"})},
+		{name: "array", in: arr("$(params.name)", "$(params.list[*])"), want: arr("world", "a", "b", "c")},
+		{name: "object", in: obj(map[string]string{"url": "http://$(params.config.host)"}), want: obj(map[string]string{"url": "http://example.com"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := substituteValue(tt.in, testParams)
+			if err != nil {
+				t.Fatalf("substituteValue(%+v) unexpected error: %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("substituteValue(%+v) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskParams(t *testing.T) {
+	def := func(v v1alpha1.ParamValue) *v1alpha1.ParamValue { return &v }
+
+	tests := []struct {
+		name    string
+		spec    v1alpha1.TaskRunSpec
+		want    map[string]v1alpha1.ParamValue
+		wantErr string
+	}{
+		{
+			name: "no params",
+			spec: v1alpha1.TaskRunSpec{},
+			want: map[string]v1alpha1.ParamValue{},
+		},
+		{
+			name: "values & defaults",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{
+					{Name: "set", Default: def(str("unused"))},
+					{Name: "defaulted", Type: v1alpha1.ParamTypeArray, Default: def(arr("x"))},
+				},
+				Params: []v1alpha1.Param{{Name: "set", Value: str("v")}},
+			},
+			want: map[string]v1alpha1.ParamValue{"set": str("v"), "defaulted": arr("x")},
+		},
+		{
+			name: "value without a type is a string",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{{Name: "p"}},
+				Params:     []v1alpha1.Param{{Name: "p", Value: v1alpha1.ParamValue{StringVal: "v"}}},
+			},
+			want: map[string]v1alpha1.ParamValue{"p": {StringVal: "v"}},
+		},
+		{
+			name: "first declared param without a value",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{{Name: "b"}, {Name: "a"}},
+			},
+			wantErr: `param "b" has no value`,
+		},
+		{
+			name: "param that isn't declared",
+			spec: v1alpha1.TaskRunSpec{
+				Params: []v1alpha1.Param{{Name: "p", Value: str("v")}},
+			},
+			wantErr: `param "p" isn't declared by the task`,
+		},
+		{
+			name: "value of the wrong type",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{{Name: "p", Type: v1alpha1.ParamTypeObject}},
+				Params:     []v1alpha1.Param{{Name: "p", Value: arr("v")}},
+			},
+			wantErr: `value of object param "p" is of type array`,
+		},
+		{
+			name: "default of the wrong type",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{{Name: "p", Type: v1alpha1.ParamTypeArray, Default: def(str("v"))}},
+			},
+			wantErr: `default of array param "p" is of type string`,
+		},
+		{
+			name: "invalid type",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{{Name: "p", Type: "number"}},
+			},
+			wantErr: `param "p" has an invalid type "number"`,
+		},
+		{
+			name: "invalid name",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{{Name: "1p"}},
+			},
+			wantErr: `invalid param name "1p"`,
+		},
+		{
+			name: "declared twice",
+			spec: v1alpha1.TaskRunSpec{
+				ParamSpecs: []v1alpha1.ParamSpec{{Name: "p", Default: def(str(""))}, {Name: "p", Default: def(str(""))}},
+			},
+			wantErr: `param "p" is declared more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := taskParams(&tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("taskParams() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("taskParams() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveSteps(t *testing.T) {
+	spec := &v1alpha1.TaskRunSpec{
+		ParamSpecs: []v1alpha1.ParamSpec{{Name: "files", Type: v1alpha1.ParamTypeArray}},
+		Params:     []v1alpha1.Param{{Name: "files", Value: arr("a.txt", "b.txt")}},
+		Results:    []v1alpha1.ResultSpec{{Name: "digest"}},
+		Workspaces: []v1alpha1.WorkspaceDeclaration{{Name: "src"}, {Name: "cache", Optional: true}},
+		WorkspaceBindings: []v1alpha1.WorkspaceBinding{
+			{Name: "src", EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		Steps: []v1alpha1.Step{{
+			Name:       "hash",
+			Image:      "busybox",
+			Command:    []string{"sha256sum", "$(params.files[*])"},
+			Args:       []string{"--cache=$(workspaces.cache.path)"},
+			WorkingDir: "$(workspaces.src.path)",
+			Env:        []corev1.EnvVar{{Name: "OUT", Value: "$(results.digest.path)"}},
+		}},
+	}
+	steps, err := resolveSteps(spec)
+	if err != nil {
+		t.Fatalf("resolveSteps() unexpected error: %v", err)
+	}
+	want := v1alpha1.Step{
+		Name:       "hash",
+		Image:      "busybox",
+		Command:    []string{"sha256sum", "a.txt", "b.txt"},
+		Args:       []string{"--cache="},
+		WorkingDir: "/workspace/src",
+		Env:        []corev1.EnvVar{{Name: "OUT", Value: "/aj/results/digest"}},
+	}
+	if len(steps) != 1 || !reflect.DeepEqual(steps[0], want) {
+		t.Errorf("resolveSteps() = %+v, want [%+v]", steps, want)
+	}
+	// spec of TaskRun is left as is.
+	if spec.Steps[0].Command[1] != "$(params.files[*])" {
+		t.Errorf("resolveSteps() has modified the steps of spec: %+v", spec.Steps[0])
+	}
+
+	for _, ref := range []string{"$(results.missing.path)", "$(workspaces.missing.path)", "$(params.missing)"} {
+		spec := spec.DeepCopy()
+		spec.Steps[0].Script = ref
+		if _, err := resolveSteps(spec); err == nil || !strings.Contains(err.Error(), `"missing"`) {
+			t.Errorf("resolveSteps() of %s error = %v, want the unknown reference reported", ref, err)
+		}
+	}
+}
@@ -3,38 +3,68 @@ package pipelinerun
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
 	pClientSet "github.com/apoorvajagtap/trackPodCRD/pkg/client/clientset/versioned"
+	pScheme "github.com/apoorvajagtap/trackPodCRD/pkg/client/clientset/versioned/scheme"
 	pInformer "github.com/apoorvajagtap/trackPodCRD/pkg/client/informers/externalversions/pipeline/v1alpha1"
 	pLister "github.com/apoorvajagtap/trackPodCRD/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/apoorvajagtap/trackPodCRD/pkg/controller/archive"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	coreInformer "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	coreLister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
+const controllerAgentName = "pipelinerun-controller"
+
 const (
-	// SuccessSynced is used as part of the Event 'reason' when a Foo is synced
+	// SuccessSynced is used as part of the Event 'reason' when a PipelineRun is synced
 	SuccessSynced = "Synced"
-	// ErrResourceExists is used as part of the Event 'reason' when a Foo fails
-	// to sync due to a Deployment of the same name already existing.
+	// ErrResourceExists is used as part of the Event 'reason' when a PipelineRun fails
+	// to sync due to a TaskRun of the same name already existing.
 	ErrResourceExists = "ErrResourceExists"
 
 	// MessageResourceExists is the message used for Events when a resource
-	// fails to sync due to a Deployment already existing
-	MessageResourceExists = "Resource %q already exists and is not managed by TrackPod"
-	// MessageResourceSynced is the message used for an Event fired when a Foo
-	// is synced successfully
-	MessageResourceSynced = "TrackPod synced successfully"
+	// fails to sync due to a TaskRun already existing
+	MessageResourceExists = "Resource %q already exists and is not managed by PipelineRun"
+	// MessageResourceSynced is the message used for an Event fired when a
+	// PipelineRun is synced successfully, i.e. its TaskRun & pods are created.
+	MessageResourceSynced = "PipelineRun synced successfully"
+
+	// Event reasons for the actions taken on PipelineRun, TaskRun & pods
+	SuccessfulCreate = "SuccessfulCreate"
+	FailedCreate     = "FailedCreate"
+	SuccessfulDelete = "SuccessfulDelete"
+	FailedDelete     = "FailedDelete"
+	FailedSync       = "FailedSync"
+	Draining         = "Draining"
+	Archived         = "Archived"
+	Finalized        = "Finalized"
+
+	// taskruns not completing within podTimeout are reported.
+	podTimeout = 10 * time.Minute
+	// PipelineRuns with the taskrun still in progress are checked again
+	// after requeueInterval, in case no pod event triggers it before.
+	requeueInterval = 30 * time.Second
 )
 
-// Controller implementation for TrackPod resources
-// TODO: Record events
+// Controller implementation for PipelineRun resources
 type Controller struct {
 	// K8s clientset
 	kubeClient kubernetes.Interface
@@ -47,7 +77,12 @@ type Controller struct {
 	prunLister pLister.PipelineRunLister
 
 	// taskrun specific lisers
+	trunSync   cache.InformerSynced
 	trunLister pLister.TaskRunLister
+
+	// pods created for the taskruns are queried from the pod cache.
+	podSync   cache.InformerSynced
+	podLister coreLister.PodLister
 	// - queue
 	// stores the work that has to be processed, instead of performing
 	// as soon as it's changed.
@@ -55,17 +90,37 @@ type Controller struct {
 	// time, and makes it easy to ensure we are never processing the same item
 	// simultaneously in two different workers.
 	wq workqueue.RateLimitingInterface
+	// number of times a failing key is retried, before it's dropped
+	// out of the queue.
+	maxRetries int
+	// recorder is an event recorder for recording Event resources to the
+	// Kubernetes API.
+	recorder record.EventRecorder
 }
 
-// returns a new TrackPod controller
-func NewController(kubeClient kubernetes.Interface, prunClient pClientSet.Interface, prunInformer pInformer.PipelineRunInformer, trunInformer pInformer.TaskRunInformer) *Controller {
+// returns a new PipelineRun controller
+func NewController(kubeClient kubernetes.Interface, prunClient pClientSet.Interface, prunInformer pInformer.PipelineRunInformer, trunInformer pInformer.TaskRunInformer, podInformer coreInformer.PodInformer, maxRetries int) *Controller {
+	// Add pipeline types to the default Kubernetes Scheme so Events can be
+	// logged for pipeline types.
+	utilruntime.Must(pScheme.AddToScheme(scheme.Scheme))
+	klog.V(4).Info("Creating event broadcaster")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+
 	c := &Controller{
 		kubeClient: kubeClient,
 		prunClient: prunClient,
 		prunSync:   prunInformer.Informer().HasSynced,
 		prunLister: prunInformer.Lister(),
+		trunSync:   trunInformer.Informer().HasSynced,
 		trunLister: trunInformer.Lister(),
+		podSync:    podInformer.Informer().HasSynced,
+		podLister:  podInformer.Lister(),
 		wq:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "PipelineRun"),
+		maxRetries: maxRetries,
+		recorder:   recorder,
 	}
 
 	// event handler when the pipelineRun resources are added/deleted/updated.
@@ -84,6 +139,39 @@ func NewController(kubeClient kubernetes.Interface, prunClient pClientSet.Interf
 		},
 	)
 
+	// event handler when the taskruns are updated (e.g. cancelled) or
+	// deleted, the PipelineRun owning the taskrun is queued to be reconciled.
+	trunInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				oldTrun := old.(*v1alpha1.TaskRun)
+				newTrun := obj.(*v1alpha1.TaskRun)
+				if newTrun.ResourceVersion == oldTrun.ResourceVersion {
+					return
+				}
+				c.enqueueOwner(newTrun)
+			},
+			DeleteFunc: c.handleTaskRunDel,
+		},
+	)
+
+	// event handler when the pods of a taskrun are added/deleted/updated,
+	// the PipelineRun owning the taskrun is queued to be reconciled.
+	podInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.handlePod,
+			UpdateFunc: func(old, obj interface{}) {
+				oldPod := old.(*corev1.Pod)
+				newPod := obj.(*corev1.Pod)
+				if newPod.ResourceVersion == oldPod.ResourceVersion {
+					return
+				}
+				c.handlePod(obj)
+			},
+			DeleteFunc: c.handlePod,
+		},
+	)
+
 	return c
 }
 
@@ -91,22 +179,26 @@ func NewController(kubeClient kubernetes.Interface, prunClient pClientSet.Interf
 // as syncing informer caches and starting workers. It will block until ch
 // is closed, at which point it will shutdown the workqueue and wait for
 // workers to finish processing their current work items.
-func (c *Controller) Run(ch chan struct{}) error {
+func (c *Controller) Run(workers int, ch <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
 	defer c.wq.ShutDown()
 
 	// Start the informer factories to begin populating the informer caches
 	klog.Info("Starting the PipelineRun controller")
 
 	// Wait for the caches to be synced before starting workers
-	if ok := cache.WaitForCacheSync(ch, c.prunSync); !ok {
-		log.Println("failed to wait for cache to sync")
+	if ok := cache.WaitForCacheSync(ch, c.prunSync, c.trunSync, c.podSync); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+	// Launch the workers to process the CR, each item of the queue is only
+	// processed by a single worker at a time.
+	klog.Infof("Starting %d workers", workers)
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, ch)
 	}
-	// Launch the goroutine for workers to process the CR
-	klog.Info("Starting workers")
-	go wait.Until(c.worker, time.Second, ch)
 	klog.Info("Started workers")
 	<-ch
-	klog.Info("Shutting down the worker")
+	klog.Info("Shutting down the workers")
 
 	return nil
 }
@@ -127,134 +219,527 @@ func (c *Controller) processNextItem() bool {
 		klog.Info("Shutting down")
 		return false
 	}
+	// Done has to be called on the item, once it's processed. Otherwise the
+	// workqueue won't hand out the key again, even if it's added back.
+	defer c.wq.Done(item)
+
+	// queue only carries the namespace/name keys, the object itself is
+	// fetched from the lister for an up to date copy.
+	key, ok := item.(string)
+	if !ok {
+		// invalid item, no point retrying it.
+		c.wq.Forget(item)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", item))
+		return true
+	}
 
-	defer c.wq.Forget(item)
-	key, err := cache.MetaNamespaceKeyFunc(item)
-	if err != nil {
-		klog.Errorf("error while calling Namespace Key func on cache for item %s: %s", item, err.Error())
-		return false
+	running, err := c.reconcile(key)
+	c.handleErr(err, key)
+	if err == nil && running {
+		c.wq.AddAfter(key, requeueInterval)
+	}
+
+	return true
+}
+
+// handleErr forgets the key on success, so that its rate limit history is
+// reset. Failed keys are retried with the rate limiter, until maxRetries.
+func (c *Controller) handleErr(err error, key string) {
+	if err == nil {
+		c.wq.Forget(key)
+		return
+	}
+
+	if c.wq.NumRequeues(key) < c.maxRetries {
+		klog.Errorf("error syncing PipelineRun %q, retrying: %v", key, err)
+		c.wq.AddRateLimited(key)
+		return
 	}
 
+	c.wq.Forget(key)
+	utilruntime.HandleError(fmt.Errorf("dropping PipelineRun %q out of the queue after %d retries: %v", key, c.maxRetries, err))
+}
+
+// reconcile syncs the PipelineRun of key & the taskruns of its tasks. Returns
+// whether any taskrun is still running & the PipelineRun has to be checked
+// again.
+func (c *Controller) reconcile(key string) (bool, error) {
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
-		klog.Errorf("error while splitting key into namespace & name: %s", err.Error())
-		return false
+		// invalid key, retrying won't help.
+		utilruntime.HandleError(fmt.Errorf("invalid resource key %q: %v", key, err))
+		return false, nil
 	}
 
 	prun, err := c.prunLister.PipelineRuns(ns).Get(name)
 	if err != nil {
-		klog.Errorf("error %s, Getting the prun resource from lister.", err.Error())
-		return false
+		if errors.IsNotFound(err) {
+			// PipelineRun is deleted, its taskruns have already been
+			// cleaned up by the finalizer.
+			klog.V(4).Infof("PipelineRun %q has been deleted", key)
+			return false, nil
+		}
+		return false, err
 	}
 
-	trun, err := c.syncHandler(prun)
-	if err != nil {
-		klog.Errorf("Error creating the TaskRun for %s PipelineRun", prun.Name, err.Error())
-		return false
+	// deleted PipelineRun is only cleaned up, no taskruns are created for it.
+	if !prun.DeletionTimestamp.IsZero() {
+		return c.finalizePipelineRun(prun)
+	}
+	// taskruns deleted on their own are drained as well.
+	if _, err := c.finalizeTaskRuns(prun, false); err != nil {
+		return false, fmt.Errorf("finalizing the TaskRuns of PipelineRun %s: %w", prun.Name, err)
+	}
+	if !hasFinalizer(prun) {
+		if err := c.addFinalizer(prun); err != nil {
+			return false, fmt.Errorf("adding finalizer to PipelineRun %s: %w", prun.Name, err)
+		}
+		// update event of the finalizer gets it reconciled again.
+		return false, nil
 	}
 
-	if trun != nil {
-		// wait for pods to be ready
-		if err := c.waitForPods(trun); err != nil {
-			klog.Errorf("error %s, waiting for pods to meet the expected state", err.Error())
+	if prun.Spec.Cancelled {
+		if err := c.cancelPipelineRun(prun); err != nil {
+			c.recorder.Event(prun, corev1.EventTypeWarning, FailedSync, err.Error())
+			return false, fmt.Errorf("cancelling PipelineRun %s: %w", prun.Name, err)
 		}
+		return false, nil
+	}
+	// pipeline is run once per generation of PipelineRun.
+	if cond := meta.FindStatusCondition(prun.Status.Conditions, v1alpha1.ConditionSucceeded); isFinished(prun.Status.Conditions) && cond.ObservedGeneration == prun.Generation {
+		return false, nil
+	}
 
-		// update taskrun status
-		if err := c.updateTrunStatus(trun); err != nil {
-			klog.Errorf("error %s updating PipelineRun status", err.Error())
+	tasks, err := sortTasks(pipelineTasks(prun))
+	if err == nil {
+		tasks, err = resolveWorkspaces(prun, tasks)
+	}
+	if err == nil {
+		tasks, err = resolveParams(prun, tasks)
+	}
+	if err != nil {
+		// there's no point retrying it, until the tasks are changed.
+		if err := c.invalidPipelineRun(prun, err); err != nil {
+			return false, fmt.Errorf("updating PipelineRun status: %w", err)
 		}
+		return false, nil
+	}
 
-		// update pipelinerun status
-		if err = c.updatePrunStatus(prun, trun); err != nil {
-			klog.Errorf("error %s updating PipelineRun status", err.Error())
+	truns, err := c.taskRuns(prun, tasks)
+	if err == nil {
+		err = c.syncHandler(prun, tasks, truns)
+	}
+	if err != nil {
+		c.recorder.Event(prun, corev1.EventTypeWarning, FailedSync, err.Error())
+		return false, fmt.Errorf("syncing the TaskRuns for PipelineRun %s: %w", prun.Name, err)
+	}
+
+	// create the missing pods of the running taskruns & update their
+	// statuses, with the pods completed so far. The pod events (or the
+	// requeue) get them updated again until all the pods are completed.
+	running := false
+	for name, trun := range truns {
+		if trun.Spec.Cancelled {
+			trun, err = c.cancelTaskRun(trun)
+		} else {
+			if !isFinished(trun.Status.Conditions) {
+				if err := c.createPodTask(prun, trun); err != nil {
+					return false, fmt.Errorf("creating the pods of TaskRun %s: %w", trun.Name, err)
+				}
+			}
+			trun, err = c.updateTrunStatus(trun)
+		}
+		if err != nil {
+			return false, fmt.Errorf("updating TaskRun status: %w", err)
 		}
+		truns[name] = trun
 
-		if prun.Status.Count == c.totalCompletedPods(trun) {
-			// fmt.Printf("About to mark trun %v as done\n", trun.Name)
-			c.wq.Done(trun)
+		if !isFinished(trun.Status.Conditions) {
+			running = true
 		}
 	}
 
-	return true
+	// update pipelinerun status
+	if err = c.updatePrunStatus(prun, tasks, truns); err != nil {
+		return false, fmt.Errorf("updating PipelineRun status: %w", err)
+	}
+
+	return running, nil
 }
 
-// syncHandler checks the status of pipeline, if current != desired, meets the requirement.
-func (c *Controller) syncHandler(prun *v1alpha1.PipelineRun) (*v1alpha1.TaskRun, error) {
-	var createTrun bool
+// returns the taskruns of the current generation of PipelineRun, by the name
+// of their tasks. Tasks that aren't started yet have none.
+func (c *Controller) taskRuns(prun *v1alpha1.PipelineRun, tasks []v1alpha1.PipelineTask) (map[string]*v1alpha1.TaskRun, error) {
+	truns := map[string]*v1alpha1.TaskRun{}
+	for _, task := range tasks {
+		trun, err := c.trunLister.TaskRuns(prun.Namespace).Get(taskRunName(prun, task.Name))
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// taskrun of the same name, not created for this PipelineRun.
+		if !metav1.IsControlledBy(trun, prun) {
+			msg := fmt.Sprintf(MessageResourceExists, trun.Name)
+			c.recorder.Event(prun, corev1.EventTypeWarning, ErrResourceExists, msg)
+			return nil, fmt.Errorf("%s", msg)
+		}
+		truns[task.Name] = trun
+	}
+	return truns, nil
+}
 
-	fmt.Println("starting the check")
-	if prun.Spec.Message != prun.Status.Message || prun.Spec.Count != prun.Status.Count {
-		fmt.Println("Should have entered check inside syncHandler >>>>>>>>>>>> ")
-		createTrun = true
+// syncHandler starts the tasks of pipeline, whose runAfter tasks have all
+// succeeded. Tasks ready at the same time are run in parallel, no new tasks
+// are started once any of them has failed. The created taskruns are added
+// to truns.
+func (c *Controller) syncHandler(prun *v1alpha1.PipelineRun, tasks []v1alpha1.PipelineTask, truns map[string]*v1alpha1.TaskRun) error {
+	if failedTask(tasks, truns) != nil {
+		return nil
 	}
 
-	fmt.Println("reached here >>>>>>>>>>>>>>>>>>>>>>> ", prun.Status, prun.Spec)
+	claimed := false
+	for _, task := range tasks {
+		if truns[task.Name] != nil || !isReady(task, truns) {
+			continue
+		}
+
+		// claims of the workspaces are there before any of the tasks
+		// using them.
+		if !claimed {
+			if err := c.createClaims(prun); err != nil {
+				return err
+			}
+			claimed = true
+		}
 
-	// create the taskrun CR.
-	if createTrun {
-		fmt.Println("Inside the condition to createTrun")
-		trun, err := c.prunClient.AjV1alpha1().TaskRuns(prun.Namespace).Create(context.TODO(), newTaskRun(prun), metav1.CreateOptions{})
+		// results of the tasks it depends on are known by now.
+		spec, err := resolveResults(task, truns)
 		if err != nil {
-			klog.Errorf("TaskRun creation failed for Pipeline %s", prun.Name)
-			return nil, err
+			return err
 		}
+		task.TaskRunSpec = spec
 
-		if trun != nil {
-			klog.Infof("Taskrun %s has been created for PipelineRun %s", trun.Name, prun.Name)
-			if err := c.createPodTask(prun, trun); err != nil {
-				klog.Errorf("Taskrun %s failed to create pods", trun.Name)
-			}
+		klog.V(4).Infof("starting task %s of PipelineRun %s", task.Name, prun.Name)
+		trun, err := c.prunClient.AjV1alpha1().TaskRuns(prun.Namespace).Create(context.TODO(), newTaskRun(prun, task), metav1.CreateOptions{})
+		if err != nil {
+			klog.Errorf("TaskRun creation failed for Pipeline %s", prun.Name)
+			c.recorder.Eventf(prun, corev1.EventTypeWarning, FailedCreate, "Error creating TaskRun: %v", err)
+			return err
 		}
 
-		return trun, nil
+		klog.Infof("Taskrun %s has been created for PipelineRun %s", trun.Name, prun.Name)
+		c.recorder.Eventf(prun, corev1.EventTypeNormal, SuccessfulCreate, "Created TaskRun: %s", trun.Name)
+		truns[task.Name] = trun
+	}
+
+	return nil
+}
+
+// task is ready to be started once all the tasks it depends on have
+// succeeded.
+func isReady(task v1alpha1.PipelineTask, truns map[string]*v1alpha1.TaskRun) bool {
+	for _, dep := range taskDeps(task) {
+		trun := truns[dep]
+		if trun == nil || !meta.IsStatusConditionTrue(trun.Status.Conditions, v1alpha1.ConditionSucceeded) {
+			return false
+		}
 	}
+	return true
+}
 
-	return nil, nil
+// returns the first taskrun of the tasks that has failed (or was
+// cancelled), nil if there's none.
+func failedTask(tasks []v1alpha1.PipelineTask, truns map[string]*v1alpha1.TaskRun) *v1alpha1.TaskRun {
+	for _, task := range tasks {
+		if trun := truns[task.Name]; trun != nil && meta.IsStatusConditionFalse(trun.Status.Conditions, v1alpha1.ConditionSucceeded) {
+			return trun
+		}
+	}
+	return nil
 }
 
-// Creates the new pod with the specified template
-func newTaskRun(prun *v1alpha1.PipelineRun) *v1alpha1.TaskRun {
-	return &v1alpha1.TaskRun{
+// Creates the new taskrun for the task of pipeline
+func newTaskRun(prun *v1alpha1.PipelineRun, task v1alpha1.PipelineTask) *v1alpha1.TaskRun {
+	trun := &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%v-trun-%v", prun.Name, prun.ObjectMeta.Generation),
+			Name:      taskRunName(prun, task.Name),
 			Namespace: prun.Namespace,
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(prun, v1alpha1.SchemeGroupVersion.WithKind("PipelineRun")),
 			},
+			Finalizers: []string{finalizerName},
 		},
-		Spec: v1alpha1.TaskRunSpec{
-			Message: prun.Spec.Message,
-			Count:   prun.Spec.Count,
-		},
+		Spec: *task.TaskRunSpec.DeepCopy(),
+	}
+	// taskruns drain their pods with the grace period of PipelineRun,
+	// unless the task has one of its own.
+	if trun.Spec.DeletionGracePeriodSeconds == nil {
+		trun.Spec.DeletionGracePeriodSeconds = prun.Spec.DeletionGracePeriodSeconds
+	}
+	// taskruns of an archived PipelineRun are archived along.
+	if archive.Enabled(prun) {
+		trun.Annotations = map[string]string{archive.Annotation: "true"}
 	}
+	return trun
 }
 
-// Updates the status section of TrackPod
-func (c *Controller) updatePrunStatus(prun *v1alpha1.PipelineRun, trun *v1alpha1.TaskRun) error {
+// name of the taskrun of task, for current generation of the pipeline
+func taskRunName(prun *v1alpha1.PipelineRun, task string) string {
+	return fmt.Sprintf("%v-%v-%v", prun.Name, task, prun.ObjectMeta.Generation)
+}
+
+// marks PipelineRun of invalid tasks as failed, without running any of them.
+func (c *Controller) invalidPipelineRun(prun *v1alpha1.PipelineRun, err error) error {
+	p := prun.DeepCopy()
+	p.Status.ObservedGeneration = prun.Generation
+	now := metav1.Now()
+	p.Status.CompletionTime = &now
+	setRunCondition(&p.Status.Conditions, prun.Generation, metav1.ConditionFalse, v1alpha1.ReasonInvalidPipeline, err.Error())
 
-	p, err := c.prunClient.AjV1alpha1().PipelineRuns(prun.Namespace).Get(context.Background(), prun.Name, metav1.GetOptions{})
+	_, err = c.prunClient.AjV1alpha1().PipelineRuns(prun.Namespace).UpdateStatus(context.Background(), p, metav1.UpdateOptions{})
 	if err != nil {
 		return err
 	}
+	c.recordFinished(prun, prun.Status.Conditions, p.Status.Conditions)
+	return nil
+}
+
+// cancels the taskruns of PipelineRun that are yet to finish, and marks the
+// PipelineRun as cancelled.
+func (c *Controller) cancelPipelineRun(prun *v1alpha1.PipelineRun) error {
+	if isFinished(prun.Status.Conditions) {
+		return nil
+	}
 
-	t, err := c.prunClient.AjV1alpha1().TaskRuns(trun.Namespace).Get(context.Background(), trun.Name, metav1.GetOptions{})
+	truns, err := c.trunLister.TaskRuns(prun.Namespace).List(labels.Everything())
 	if err != nil {
 		return err
 	}
+	for _, trun := range truns {
+		if !metav1.IsControlledBy(trun, prun) || isFinished(trun.Status.Conditions) {
+			continue
+		}
+		if !trun.Spec.Cancelled {
+			t := trun.DeepCopy()
+			t.Spec.Cancelled = true
+			trun, err = c.prunClient.AjV1alpha1().TaskRuns(t.Namespace).Update(context.Background(), t, metav1.UpdateOptions{})
+			if err != nil {
+				return err
+			}
+		}
+		if _, err := c.cancelTaskRun(trun); err != nil {
+			return err
+		}
+	}
 
-	p.Status.Count = t.Status.Count
-	p.Status.Message = t.Status.Message
+	p := prun.DeepCopy()
+	p.Status.ObservedGeneration = prun.Generation
+	now := metav1.Now()
+	p.Status.CompletionTime = &now
+	// tasks yet to start won't be run anymore.
+	for i, task := range p.Status.Tasks {
+		switch task.Reason {
+		case v1alpha1.ReasonPending:
+			p.Status.Tasks[i].Reason = v1alpha1.ReasonSkipped
+		case v1alpha1.ReasonRunning:
+			p.Status.Tasks[i].Reason = v1alpha1.ReasonCancelled
+		}
+	}
+	setRunCondition(&p.Status.Conditions, prun.Generation, metav1.ConditionFalse, v1alpha1.ReasonCancelled,
+		fmt.Sprintf("PipelineRun %s was cancelled", prun.Name))
 
 	_, err = c.prunClient.AjV1alpha1().PipelineRuns(prun.Namespace).UpdateStatus(context.Background(), p, metav1.UpdateOptions{})
-	return err
+	if err != nil {
+		return err
+	}
+	c.recorder.Event(prun, corev1.EventTypeNormal, v1alpha1.ReasonCancelled, "PipelineRun was cancelled")
+	return nil
+}
+
+// Updates the status section of PipelineRun, with the status of the taskruns
+// of its tasks. The pipeline has finished once none of the taskruns is
+// running, & has succeeded if all the tasks have.
+func (c *Controller) updatePrunStatus(prun *v1alpha1.PipelineRun, tasks []v1alpha1.PipelineTask, truns map[string]*v1alpha1.TaskRun) error {
+	p := prun.DeepCopy()
+	p.Status.ObservedGeneration = prun.Generation
+	p.Status.Message = ""
+	p.Status.Count = 0
+	p.Status.Tasks = nil
+	p.Status.StartTime = nil
+	p.Status.CompletionTime = nil
+
+	failed := failedTask(tasks, truns)
+	running, succeeded := 0, 0
+	for _, task := range tasks {
+		status := v1alpha1.PipelineTaskStatus{Name: task.Name, Reason: v1alpha1.ReasonPending}
+		trun := truns[task.Name]
+		switch {
+		case trun == nil && failed != nil:
+			status.Reason = v1alpha1.ReasonSkipped
+		case trun != nil:
+			status.TaskRunName = trun.Name
+			status.Reason = v1alpha1.ReasonRunning
+			if cond := meta.FindStatusCondition(trun.Status.Conditions, v1alpha1.ConditionSucceeded); cond != nil {
+				status.Reason = cond.Reason
+			}
+			if !isFinished(trun.Status.Conditions) {
+				running++
+			} else if status.Reason == v1alpha1.ReasonSucceeded {
+				succeeded++
+			}
+
+			p.Status.Count += trun.Status.Count
+			if start := trun.Status.StartTime; start != nil && (p.Status.StartTime == nil || start.Before(p.Status.StartTime)) {
+				p.Status.StartTime = start
+			}
+			if end := trun.Status.CompletionTime; end != nil && (p.Status.CompletionTime == nil || p.Status.CompletionTime.Before(end)) {
+				p.Status.CompletionTime = end
+			}
+		}
+		p.Status.Tasks = append(p.Status.Tasks, status)
+	}
+	if len(tasks) == 1 && truns[tasks[0].Name] != nil {
+		p.Status.Message = truns[tasks[0].Name].Status.Message
+	}
+
+	progress := fmt.Sprintf("%d/%d tasks completed", succeeded, len(tasks))
+	switch {
+	case running == 0 && failed != nil:
+		cond := meta.FindStatusCondition(failed.Status.Conditions, v1alpha1.ConditionSucceeded)
+		setRunCondition(&p.Status.Conditions, prun.Generation, metav1.ConditionFalse, cond.Reason,
+			fmt.Sprintf("TaskRun %s: %s", failed.Name, cond.Message))
+	case succeeded == len(tasks):
+		setRunCondition(&p.Status.Conditions, prun.Generation, metav1.ConditionTrue, v1alpha1.ReasonSucceeded, progress)
+	default:
+		// pipeline waits on the running taskruns, before it's failed.
+		p.Status.CompletionTime = nil
+		setRunCondition(&p.Status.Conditions, prun.Generation, metav1.ConditionUnknown, v1alpha1.ReasonRunning, progress)
+	}
+
+	if equality.Semantic.DeepEqual(prun.Status, p.Status) {
+		return nil
+	}
+	_, err := c.prunClient.AjV1alpha1().PipelineRuns(prun.Namespace).UpdateStatus(context.Background(), p, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	c.recordFinished(prun, prun.Status.Conditions, p.Status.Conditions)
+	return nil
 }
 
 func (c *Controller) handlePipelineAdd(obj interface{}) {
-	klog.Info("handlePipelineAdd is here!!!")
-	c.wq.Add(obj)
+	c.enqueue(obj)
 }
 
+// deleted PipelineRuns are queued as well, the reconcile finds them missing
+// from the lister & treats it as a successful deletion.
 func (c *Controller) handlePipelineDel(obj interface{}) {
-	klog.Info("handlePipelineDel is here!!")
-	c.wq.Done(obj)
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.wq.Add(key)
+}
+
+// converts the object into a namespace/name key & puts it onto the queue.
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.wq.Add(key)
+}
+
+// enqueues the PipelineRun owning the TaskRun that controls the pod, any
+// other pod is ignored.
+func (c *Controller) handlePod(obj interface{}) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("error decoding object, invalid type %T", obj)
+			return
+		}
+		pod, ok = tombstone.Obj.(*corev1.Pod)
+		if !ok {
+			klog.Errorf("error decoding object tombstone, invalid type %T", tombstone.Obj)
+			return
+		}
+	}
+
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil || ownerRef.Kind != "TaskRun" {
+		return
+	}
+	trun, err := c.trunLister.TaskRuns(pod.Namespace).Get(ownerRef.Name)
+	if err != nil || trun.UID != ownerRef.UID {
+		return
+	}
+	c.enqueueOwner(trun)
+}
+
+// enqueues the PipelineRun of a deleted taskrun, that might be waiting on
+// the taskrun to be gone.
+func (c *Controller) handleTaskRunDel(obj interface{}) {
+	trun, ok := obj.(*v1alpha1.TaskRun)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("error decoding object, invalid type %T", obj)
+			return
+		}
+		trun, ok = tombstone.Obj.(*v1alpha1.TaskRun)
+		if !ok {
+			klog.Errorf("error decoding object tombstone, invalid type %T", tombstone.Obj)
+			return
+		}
+	}
+	c.enqueueOwner(trun)
+}
+
+// enqueues the PipelineRun that controls the taskrun
+func (c *Controller) enqueueOwner(trun *v1alpha1.TaskRun) {
+	ownerRef := metav1.GetControllerOf(trun)
+	if ownerRef == nil || ownerRef.Kind != "PipelineRun" {
+		return
+	}
+	prun, err := c.prunLister.PipelineRuns(trun.Namespace).Get(ownerRef.Name)
+	if err != nil || prun.UID != ownerRef.UID {
+		return
+	}
+	c.enqueue(prun)
+}
+
+// sets the Succeeded condition of a run
+func setRunCondition(conditions *[]metav1.Condition, generation int64, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionSucceeded,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
+
+// records an event on the run, if it has just finished.
+func (c *Controller) recordFinished(run runtime.Object, old, new []metav1.Condition) {
+	if isFinished(old) || !isFinished(new) {
+		return
+	}
+	cond := meta.FindStatusCondition(new, v1alpha1.ConditionSucceeded)
+	eventType := corev1.EventTypeNormal
+	if cond.Reason == v1alpha1.ReasonFailed || cond.Reason == v1alpha1.ReasonInvalidPipeline {
+		eventType = corev1.EventTypeWarning
+	}
+	c.recorder.Event(run, eventType, cond.Reason, cond.Message)
+}
+
+// run has finished once its Succeeded condition is either True or False
+func isFinished(conditions []metav1.Condition) bool {
+	cond := meta.FindStatusCondition(conditions, v1alpha1.ConditionSucceeded)
+	return cond != nil && cond.Status != metav1.ConditionUnknown
 }
@@ -0,0 +1,264 @@
+package pipelinerun
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// steps write the results into files of resultsDir, a volume shared by
+	// the containers of the pod.
+	resultsDir    = "/aj/results"
+	resultsVolume = "results"
+	// resultsContainer runs after all the steps, & passes the results on
+	// to the controller through its termination message.
+	resultsContainer = "results"
+	resultsImage     = "busybox:1.36"
+	// maxResultsSize is the limit of the termination message, the encoded
+	// results have to fit in.
+	maxResultsSize = 4096
+)
+
+var (
+	// $(results.<name>.path) in the steps of a task
+	resultPathRef = regexp.MustCompile(`\$\(results\.([a-zA-Z0-9_-]+)\.path\)`)
+	// $(tasks.<task>.results.<name>) in the downstream tasks of a pipeline
+	taskResultRef = regexp.MustCompile(`\$\(tasks\.([a-z0-9-]+)\.results\.([a-zA-Z0-9_-]+)\)`)
+)
+
+// validates the results declared by a task.
+func validateResults(results []v1alpha1.ResultSpec) error {
+	names := map[string]bool{}
+	for _, result := range results {
+		if !paramName.MatchString(result.Name) {
+			return fmt.Errorf("invalid result name %q", result.Name)
+		}
+		if names[result.Name] {
+			return fmt.Errorf("result %q is declared more than once", result.Name)
+		}
+		names[result.Name] = true
+	}
+	return nil
+}
+
+// calls fn on each string of the task's params & steps that might refer to
+// the results of other tasks, & replaces the string with what it returns.
+func mapTaskStrings(spec *v1alpha1.TaskRunSpec, fn func(string) (string, error)) error {
+	var err error
+	each := func(list []string) error {
+		for i := range list {
+			if list[i], err = fn(list[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := range spec.Params {
+		value := &spec.Params[i].Value
+		switch value.Type {
+		case v1alpha1.ParamTypeArray:
+			err = each(value.ArrayVal)
+		case v1alpha1.ParamTypeObject:
+			for k, v := range value.ObjectVal {
+				if value.ObjectVal[k], err = fn(v); err != nil {
+					break
+				}
+			}
+		default:
+			value.StringVal, err = fn(value.StringVal)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		if step.Image, err = fn(step.Image); err != nil {
+			return err
+		}
+		if err := each(step.Command); err != nil {
+			return err
+		}
+		if err := each(step.Args); err != nil {
+			return err
+		}
+		for j := range step.Env {
+			if step.Env[j].Value, err = fn(step.Env[j].Value); err != nil {
+				return err
+			}
+		}
+		if step.WorkingDir, err = fn(step.WorkingDir); err != nil {
+			return err
+		}
+		if step.Script, err = fn(step.Script); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns the tasks the task refers to the results of, as task -> results.
+func resultRefs(task v1alpha1.PipelineTask) map[string][]string {
+	refs := map[string][]string{}
+	spec := task.TaskRunSpec.DeepCopy()
+	_ = mapTaskStrings(spec, func(s string) (string, error) {
+		for _, m := range taskResultRef.FindAllStringSubmatch(s, -1) {
+			refs[m[1]] = append(refs[m[1]], m[2])
+		}
+		return s, nil
+	})
+	return refs
+}
+
+// returns the tasks that have to succeed before the task is started, the
+// tasks it runs after & the ones whose results it refers to.
+func taskDeps(task v1alpha1.PipelineTask) []string {
+	deps := append([]string{}, task.RunAfter...)
+	refs := resultRefs(task)
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if indexOf(deps, name) < 0 {
+			deps = append(deps, name)
+		}
+	}
+	return deps
+}
+
+// returns the spec of the task's TaskRun, with the references to the results
+// of the tasks it depends on replaced by their values.
+func resolveResults(task v1alpha1.PipelineTask, truns map[string]*v1alpha1.TaskRun) (v1alpha1.TaskRunSpec, error) {
+	spec := task.TaskRunSpec.DeepCopy()
+	err := mapTaskStrings(spec, func(s string) (string, error) {
+		var err error
+		out := taskResultRef.ReplaceAllStringFunc(s, func(ref string) string {
+			m := taskResultRef.FindStringSubmatch(ref)
+			if trun := truns[m[1]]; trun != nil {
+				for _, result := range trun.Status.Results {
+					if result.Name == m[2] {
+						return result.Value
+					}
+				}
+			}
+			err = fmt.Errorf("result %q of task %q isn't available", m[2], m[1])
+			return ref
+		})
+		return out, err
+	})
+	return *spec, err
+}
+
+// replaces the $(results.<name>.path) references in s, with the path of the
+// declared result.
+func substituteResultPaths(s string, results []v1alpha1.ResultSpec) (string, error) {
+	var err error
+	out := resultPathRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := resultPathRef.FindStringSubmatch(ref)[1]
+		for _, result := range results {
+			if result.Name == name {
+				return path.Join(resultsDir, name)
+			}
+		}
+		err = fmt.Errorf("unknown result %q is referred to in %q", name, s)
+		return ref
+	})
+	return out, err
+}
+
+// sets the pod up for the results of TaskRun: all the steps are run as init
+// containers, followed by the container collecting the results written to
+// the shared volume into its termination message.
+func addResults(pod *corev1.Pod, results []v1alpha1.ResultSpec) {
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, pod.Spec.Containers...)
+
+	// each result is a name=<base64 value> line, so that values of any
+	// content fit in. Results exceeding the termination message would be
+	// truncated, they fail the container with the reason instead.
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Name)
+	}
+	script := fmt.Sprintf(`out=""
+for name in %s; do
+  if [ -f %s/$name ]; then out="$out$name=$(base64 -w0 %s/$name)
+"; fi
+done
+if [ ${#out} -gt %d ]; then
+  echo "results take ${#out} bytes once encoded, over the limit of %d bytes" > /dev/termination-log
+  exit 1
+fi
+printf '%%s' "$out" > /dev/termination-log`, strings.Join(names, " "), resultsDir, resultsDir, maxResultsSize, maxResultsSize)
+	pod.Spec.Containers = []corev1.Container{{
+		Name:                     resultsContainer,
+		Image:                    resultsImage,
+		Command:                  []string{"/bin/sh", "-c", script},
+		TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+	}}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name:         resultsVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	mount := corev1.VolumeMount{Name: resultsVolume, MountPath: resultsDir}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].VolumeMounts = append(pod.Spec.InitContainers[i].VolumeMounts, mount)
+	}
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, mount)
+}
+
+// returns the results of TaskRun, read from the first of its succeeded pods.
+// Fails when any of the declared results wasn't written.
+func taskRunResults(trun *v1alpha1.TaskRun, pods []*corev1.Pod) ([]v1alpha1.TaskRunResult, error) {
+	if len(trun.Spec.Results) == 0 {
+		return nil, nil
+	}
+
+	var pod *corev1.Pod
+	for _, p := range pods {
+		if p.Status.Phase == corev1.PodSucceeded && p.DeletionTimestamp.IsZero() && (pod == nil || p.Name < pod.Name) {
+			pod = p
+		}
+	}
+	values := map[string]string{}
+	if pod != nil {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != resultsContainer || status.State.Terminated == nil {
+				continue
+			}
+			for _, line := range strings.Split(status.State.Terminated.Message, "\n") {
+				name, encoded, ok := strings.Cut(line, "=")
+				if !ok {
+					continue
+				}
+				value, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil {
+					return nil, fmt.Errorf("result %q of pod %s can't be decoded: %w", name, pod.Name, err)
+				}
+				// trailing newline of e.g. echo isn't part of the result.
+				values[name] = strings.TrimSuffix(string(value), "\n")
+			}
+		}
+	}
+
+	results := make([]v1alpha1.TaskRunResult, 0, len(trun.Spec.Results))
+	for _, result := range trun.Spec.Results {
+		value, ok := values[result.Name]
+		if !ok {
+			return nil, fmt.Errorf("result %s wasn't written by the steps", result.Name)
+		}
+		results = append(results, v1alpha1.TaskRunResult{Name: result.Name, Value: value})
+	}
+	return results, nil
+}
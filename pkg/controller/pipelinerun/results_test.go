@@ -0,0 +1,181 @@
+package pipelinerun
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaskRun declaring the results of names.
+func trunDeclaring(names ...string) *v1alpha1.TaskRun {
+	trun := &v1alpha1.TaskRun{}
+	for _, name := range names {
+		trun.Spec.Results = append(trun.Spec.Results, v1alpha1.ResultSpec{Name: name})
+	}
+	return trun
+}
+
+// pod of phase, whose results container has terminated with message & code.
+func resultsPod(name string, phase corev1.PodPhase, code int32, message string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  resultsContainer,
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: code, Message: message}},
+			}},
+		},
+	}
+}
+
+// termination message of the results container, of name=value pairs.
+func resultsMessage(kv ...string) string {
+	var lines []string
+	for i := 0; i+1 < len(kv); i += 2 {
+		lines = append(lines, kv[i]+"="+base64.StdEncoding.EncodeToString([]byte(kv[i+1])))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestTaskRunResults(t *testing.T) {
+	deleted := resultsPod("a", corev1.PodSucceeded, 0, resultsMessage("digest", "deleted"))
+	now := metav1.NewTime(time.Now())
+	deleted.DeletionTimestamp = &now
+
+	tests := []struct {
+		name    string
+		trun    *v1alpha1.TaskRun
+		pods    []*corev1.Pod
+		want    []v1alpha1.TaskRunResult
+		wantErr string
+	}{
+		{
+			name: "no results declared",
+			trun: trunDeclaring(),
+			pods: []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, "")},
+		},
+		{
+			name: "results in the order declared",
+			trun: trunDeclaring("url", "digest"),
+			pods: []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, resultsMessage("digest", "sha256:abc", "url", "https://example.com/?a=b"))},
+			want: []v1alpha1.TaskRunResult{{Name: "url", Value: "https://example.com/?a=b"}, {Name: "digest", Value: "sha256:abc"}},
+		},
+		{
+			name: "trailing newline is trimmed",
+			trun: trunDeclaring("digest"),
+			pods: []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, resultsMessage("digest", "sha256:abc\n"))},
+			want: []v1alpha1.TaskRunResult{{Name: "digest", Value: "sha256:abc"}},
+		},
+		{
+			name: "values of several lines",
+			trun: trunDeclaring("notes"),
+			pods: []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, resultsMessage("notes", "first\nsecond\n"))},
+			want: []v1alpha1.TaskRunResult{{Name: "notes", Value: "first\nsecond"}},
+		},
+		{
+			name: "empty value",
+			trun: trunDeclaring("digest"),
+			pods: []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, resultsMessage("digest", ""))},
+			want: []v1alpha1.TaskRunResult{{Name: "digest", Value: ""}},
+		},
+		{
+			name: "results not declared are ignored",
+			trun: trunDeclaring("digest"),
+			pods: []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, resultsMessage("digest", "sha256:abc", "extra", "x"))},
+			want: []v1alpha1.TaskRunResult{{Name: "digest", Value: "sha256:abc"}},
+		},
+		{
+			name: "message just within the limit",
+			trun: trunDeclaring("blob"),
+			// name, "=" & the encoded value take maxResultsSize-3 bytes.
+			pods: []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, "blob="+base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", (maxResultsSize-len("blob="))/4*3))))},
+			want: []v1alpha1.TaskRunResult{{Name: "blob", Value: strings.Repeat("x", (maxResultsSize-len("blob="))/4*3)}},
+		},
+		{
+			name: "first succeeded pod by name",
+			trun: trunDeclaring("digest"),
+			pods: []*corev1.Pod{
+				resultsPod("c", corev1.PodSucceeded, 0, resultsMessage("digest", "c")),
+				resultsPod("a", corev1.PodRunning, 0, resultsMessage("digest", "running")),
+				deleted,
+				resultsPod("b", corev1.PodSucceeded, 0, resultsMessage("digest", "b")),
+			},
+			want: []v1alpha1.TaskRunResult{{Name: "digest", Value: "b"}},
+		},
+		{
+			name:    "result not written",
+			trun:    trunDeclaring("digest", "url"),
+			pods:    []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, resultsMessage("digest", "sha256:abc"))},
+			wantErr: "result url wasn't written by the steps",
+		},
+		{
+			name:    "no succeeded pod",
+			trun:    trunDeclaring("digest"),
+			pods:    []*corev1.Pod{resultsPod("a", corev1.PodRunning, 0, resultsMessage("digest", "sha256:abc"))},
+			wantErr: "result digest wasn't written by the steps",
+		},
+		{
+			name:    "malformed value",
+			trun:    trunDeclaring("digest"),
+			pods:    []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, `digest={"not":"base64"}`)},
+			wantErr: `result "digest" of pod a can't be decoded`,
+		},
+		{
+			name:    "truncated value",
+			trun:    trunDeclaring("digest"),
+			pods:    []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, strings.TrimSuffix(resultsMessage("digest", "sha256:abc"), "=\n"))},
+			wantErr: `result "digest" of pod a can't be decoded`,
+		},
+		{
+			name:    "lines other than results are skipped",
+			trun:    trunDeclaring("digest"),
+			pods:    []*corev1.Pod{resultsPod("a", corev1.PodSucceeded, 0, "garbage\n\n")},
+			wantErr: "result digest wasn't written by the steps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := taskRunResults(tt.trun, tt.pods)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("taskRunResults() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("taskRunResults() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskRunResults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultsSizeLimit(t *testing.T) {
+	pod := &corev1.Pod{}
+	pod.Spec.Containers = []corev1.Container{{Name: stepContainerPrefix + "build"}}
+	addResults(pod, []v1alpha1.ResultSpec{{Name: "digest"}})
+
+	// results over the limit fail the results container, instead of being
+	// truncated in its termination message.
+	script := pod.Spec.Containers[0].Command[2]
+	if !strings.Contains(script, fmt.Sprintf("-gt %d", maxResultsSize)) || !strings.Contains(script, "exit 1") {
+		t.Errorf("addResults() script doesn't fail over %d bytes:\n%s", maxResultsSize, script)
+	}
+
+	failed := resultsPod("a", corev1.PodFailed, 1, "results take 5000 bytes once encoded, over the limit of 4096 bytes\n")
+	want := "container results has exited with code 1: results take 5000 bytes once encoded, over the limit of 4096 bytes"
+	if got := failedStep(failed); got != want {
+		t.Errorf("failedStep() = %q, want %q", got, want)
+	}
+}
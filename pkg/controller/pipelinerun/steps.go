@@ -0,0 +1,89 @@
+package pipelinerun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// containers of the steps are named <stepContainerPrefix><step name>.
+const stepContainerPrefix = "step-"
+
+// validates the steps of a task, before any of its pods are created.
+func validateSteps(steps []v1alpha1.Step) error {
+	names := map[string]bool{}
+	for _, step := range steps {
+		if errs := validation.IsDNS1123Label(stepContainerPrefix + step.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid step name %q: %s", step.Name, strings.Join(errs, ", "))
+		}
+		if names[step.Name] {
+			return fmt.Errorf("step %q is defined more than once", step.Name)
+		}
+		names[step.Name] = true
+		if step.Image == "" {
+			return fmt.Errorf("step %q has no image", step.Name)
+		}
+		if step.Script != "" && len(step.Command) > 0 {
+			return fmt.Errorf("step %q can't have both a script & a command", step.Name)
+		}
+	}
+	return nil
+}
+
+// number of pods run by TaskRun, at least one pod is run for the steps.
+func podCount(trun *v1alpha1.TaskRun) int {
+	if len(trun.Spec.Steps) > 0 && trun.Spec.Count < 1 {
+		return 1
+	}
+	return trun.Spec.Count
+}
+
+// returns the init containers & containers running the steps. All the steps
+// but the last are init containers, so that the kubelet starts each of them
+// only after the previous one has succeeded, & fails the pod on the first
+// step that doesn't.
+func stepContainers(steps []v1alpha1.Step) ([]corev1.Container, []corev1.Container) {
+	var containers []corev1.Container
+	for _, step := range steps {
+		c := corev1.Container{
+			Name:       stepContainerPrefix + step.Name,
+			Image:      step.Image,
+			Command:    step.Command,
+			Args:       step.Args,
+			Env:        step.Env,
+			WorkingDir: step.WorkingDir,
+		}
+		// script gets the args as $1, $2... with the step name as $0.
+		if step.Script != "" {
+			c.Command = []string{"/bin/sh", "-c", step.Script, step.Name}
+		}
+		containers = append(containers, c)
+	}
+	last := len(containers) - 1
+	return containers[:last], containers[last:]
+}
+
+// returns why the step (or the results container) of a failed pod has
+// failed, empty if none of them has exited with an error.
+func failedStep(pod *corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		t := status.State.Terminated
+		if t == nil || t.ExitCode == 0 {
+			continue
+		}
+		// results container tells why it has failed, e.g. the results
+		// being too large.
+		if status.Name == resultsContainer && t.Message != "" {
+			return fmt.Sprintf("container %s has exited with code %d: %s", status.Name, t.ExitCode, strings.TrimSpace(t.Message))
+		}
+		if !strings.HasPrefix(status.Name, stepContainerPrefix) {
+			return fmt.Sprintf("container %s has exited with code %d", status.Name, t.ExitCode)
+		}
+		return fmt.Sprintf("step %s has exited with code %d", strings.TrimPrefix(status.Name, stepContainerPrefix), t.ExitCode)
+	}
+	return ""
+}
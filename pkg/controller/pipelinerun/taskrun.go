@@ -3,44 +3,104 @@ package pipelinerun
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"time"
 
 	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
-	"github.com/kanisterio/kanister/pkg/poll"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 )
 
+// createPodTask creates the pods of TaskRun that are missing, so that a
+// failed attempt is picked up again by the next reconcile. Pods are named
+// <taskrun>-<n>, a pod created by an earlier reconcile that isn't in the
+// cache yet is never created twice.
 func (c *Controller) createPodTask(prun *v1alpha1.PipelineRun, trun *v1alpha1.TaskRun) error {
-	// var podCreate, podDelete bool
-	iterate := trun.Spec.Count
+	pList, err := c.listPods(trun)
+	if err != nil {
+		return err
+	}
+	// names of the pods being deleted aren't reused, the pods aren't
+	// counted either.
+	names := map[string]bool{}
+	missing := podCount(trun)
+	for _, pod := range pList {
+		names[pod.Name] = true
+		if pod.DeletionTimestamp.IsZero() {
+			missing--
+		}
+	}
+	if missing <= 0 {
+		return nil
+	}
+
+	// params of the steps are checked along with the pipeline, before
+	// the TaskRun is created.
+	steps, err := resolveSteps(&trun.Spec)
+	if err != nil {
+		c.recorder.Eventf(trun, corev1.EventTypeWarning, FailedCreate, "Error resolving the params of steps: %v", err)
+		return err
+	}
 	// Creates pod
-	for i := 0; i < iterate; i++ {
-		nPod, err := c.kubeClient.CoreV1().Pods(trun.Namespace).Create(context.TODO(), newPod(trun), metav1.CreateOptions{})
+	for i := 0; missing > 0; i++ {
+		name := fmt.Sprintf("%s-%d", trun.Name, i)
+		if names[name] {
+			continue
+		}
+		nPod, err := c.kubeClient.CoreV1().Pods(trun.Namespace).Create(context.TODO(), newPod(trun, name, steps), metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			if err := c.checkPodOwner(trun, name); err != nil {
+				return err
+			}
+			missing--
+			continue
+		}
 		if err != nil {
 			klog.Errorf("Pod creation failed for CR %v\n", trun.Name)
+			c.recorder.Eventf(trun, corev1.EventTypeWarning, FailedCreate, "Error creating pod: %v", err)
 			return err
 		}
-		if nPod.Name != "" {
-			klog.Infof("Pod %v created successfully!\n", nPod.Name)
-		}
+		klog.Infof("Pod %v created successfully!\n", nPod.Name)
+		c.recorder.Eventf(trun, corev1.EventTypeNormal, SuccessfulCreate, "Created pod: %s", nPod.Name)
+		missing--
 	}
+	c.recorder.Event(prun, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
 
 	return nil
 }
 
-// Creates the new pod with the specified template
-func newPod(trun *v1alpha1.TaskRun) *corev1.Pod {
+// checks that the existing pod of the name is controlled by TaskRun, a pod of
+// the same name not created for it is never used.
+func (c *Controller) checkPodOwner(trun *v1alpha1.TaskRun, name string) error {
+	pod, err := c.kubeClient.CoreV1().Pods(trun.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(pod, trun) {
+		msg := fmt.Sprintf(MessageResourceExists, name)
+		c.recorder.Event(trun, corev1.EventTypeWarning, ErrResourceExists, msg)
+		return fmt.Errorf("%s", msg)
+	}
+	return nil
+}
+
+// Creates the new pod with the specified template, running the (resolved)
+// steps of TaskRun. Pod of a TaskRun without steps echoes its message.
+func newPod(trun *v1alpha1.TaskRun, name string, steps []v1alpha1.Step) *corev1.Pod {
 	labels := map[string]string{
-		"controller": trun.Name,
+		"controller": podsLabel(trun),
 	}
-	return &corev1.Pod{
+	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Labels:       labels,
-			GenerateName: fmt.Sprintf("%s-", trun.Name),
-			Namespace:    trun.Namespace,
+			Labels:    labels,
+			Name:      name,
+			Namespace: trun.Namespace,
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(trun, v1alpha1.SchemeGroupVersion.WithKind("TaskRun")),
 			},
@@ -68,58 +128,191 @@ func newPod(trun *v1alpha1.TaskRun) *corev1.Pod {
 			},
 		},
 	}
+	if len(steps) > 0 {
+		pod.Spec.InitContainers, pod.Spec.Containers = stepContainers(steps)
+	}
+	if len(trun.Spec.Results) > 0 {
+		addResults(pod, trun.Spec.Results)
+	}
+	addWorkspaces(pod, &trun.Spec)
+	return pod
 }
 
-// total number of 'Completed' pods
-func (c *Controller) totalCompletedPods(trun *v1alpha1.TaskRun) int {
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			"controller": trun.Name,
-		},
+// value of the controller label of the pods of TaskRun, i.e. its name. A name
+// longer than a label value can be is cut short & suffixed with its hash, so
+// that the TaskRuns sharing the prefix still label their pods apart.
+func podsLabel(trun *v1alpha1.TaskRun) string {
+	if len(trun.Name) <= validation.LabelValueMaxLength {
+		return trun.Name
+	}
+	h := fnv.New32a()
+	h.Write([]byte(trun.Name))
+	hash := fmt.Sprintf("%08x", h.Sum32())
+	return trun.Name[:validation.LabelValueMaxLength-len(hash)-1] + "-" + hash
+}
+
+// lists the pods of TaskRun from the pod cache, pods carrying the label
+// without being controlled by the TaskRun are ignored.
+func (c *Controller) listPods(trun *v1alpha1.TaskRun) ([]*corev1.Pod, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		"controller": podsLabel(trun),
+	})
+	pList, err := c.podLister.Pods(trun.Namespace).List(selector)
+	if err != nil {
+		return nil, err
 	}
-	listOptions := metav1.ListOptions{
-		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
+
+	var owned []*corev1.Pod
+	for _, pod := range pList {
+		if metav1.IsControlledBy(pod, trun) {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+// total number of 'Completed' pods
+func (c *Controller) totalCompletedPods(trun *v1alpha1.TaskRun) (int, error) {
+	pList, err := c.listPods(trun)
+	if err != nil {
+		return 0, err
 	}
-	// TODO: Prefer using podLister to reduce the call to K8s API.
-	pList, _ := c.kubeClient.CoreV1().Pods(trun.Namespace).List(context.TODO(), listOptions)
 
 	completedPods := 0
-	for _, pod := range pList.Items {
+	for _, pod := range pList {
 		if pod.ObjectMeta.DeletionTimestamp.IsZero() && pod.Status.Phase == "Succeeded" {
 			completedPods++
 		}
 	}
-	return completedPods
+	return completedPods, nil
 }
 
-// If the pod doesn't switch to a running state within 10 minutes, shall report.
-func (c *Controller) waitForPods(trun *v1alpha1.TaskRun) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-	defer cancel()
+// deletes the pods of cancelled TaskRun that are yet to complete, and marks
+// the TaskRun as cancelled.
+func (c *Controller) cancelTaskRun(trun *v1alpha1.TaskRun) (*v1alpha1.TaskRun, error) {
+	if err := c.deleteUnfinishedPods(trun); err != nil {
+		return nil, err
+	}
+	return c.updateTrunStatus(trun)
+}
 
-	return poll.Wait(ctx, func(ctx context.Context) (bool, error) {
-		completedPods := c.totalCompletedPods(trun)
-		fmt.Println("Inside waitforPods ???? totalcompletedpods >>>> ", completedPods)
+// TaskRun has timed out when its pods haven't completed within podTimeout,
+// it's only reported, the pods are left running.
+func isTimedOut(trun *v1alpha1.TaskRun) bool {
+	return !isFinished(trun.Status.Conditions) && time.Since(trun.CreationTimestamp.Time) > podTimeout
+}
 
-		if completedPods == trun.Spec.Count {
-			return true, nil
-		}
-		return false, nil
-	})
+// records an event on TaskRun, if it has just timed out.
+func (c *Controller) recordTimedOut(trun *v1alpha1.TaskRun, old, new []metav1.Condition) {
+	cond := meta.FindStatusCondition(new, v1alpha1.ConditionSucceeded)
+	if cond == nil || cond.Reason != v1alpha1.ReasonTimeout {
+		return
+	}
+	if prev := meta.FindStatusCondition(old, v1alpha1.ConditionSucceeded); prev != nil && prev.Reason == v1alpha1.ReasonTimeout {
+		return
+	}
+	klog.V(4).Infof("pods of TaskRun %s didn't complete within %v", trun.Name, podTimeout)
+	c.recorder.Event(trun, corev1.EventTypeWarning, cond.Reason, cond.Message)
 }
 
-func (c *Controller) updateTrunStatus(trun *v1alpha1.TaskRun) error {
-	t, err := c.prunClient.AjV1alpha1().TaskRuns(trun.Namespace).Get(context.Background(), trun.Name, metav1.GetOptions{})
+// deletes the pods of TaskRun that are neither completed nor being deleted.
+func (c *Controller) deleteUnfinishedPods(trun *v1alpha1.TaskRun) error {
+	pList, err := c.listPods(trun)
 	if err != nil {
 		return err
 	}
+	for _, pod := range pList {
+		if !pod.DeletionTimestamp.IsZero() || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		err := c.kubeClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			c.recorder.Eventf(trun, corev1.EventTypeWarning, FailedDelete, "Error deleting pod %s: %v", pod.Name, err)
+			return err
+		}
+		c.recorder.Eventf(trun, corev1.EventTypeNormal, SuccessfulDelete, "Deleted pod: %s", pod.Name)
+	}
+	return nil
+}
+
+// Updates the status section of TaskRun, with the pods completed so far.
+// Status of a finished TaskRun is final, & isn't updated anymore.
+func (c *Controller) updateTrunStatus(trun *v1alpha1.TaskRun) (*v1alpha1.TaskRun, error) {
+	if isFinished(trun.Status.Conditions) {
+		return trun, nil
+	}
+
+	pList, err := c.listPods(trun)
+	if err != nil {
+		return nil, err
+	}
 
-	fmt.Println("about to update trun status >>>> ", trun)
-	klog.Infof("Insider updatetrunstatus: %v ,,, %v", c.totalCompletedPods(trun), t.Spec.Message, t.Spec.Count)
+	completedPods := 0
+	var failedPod *corev1.Pod
+	for _, pod := range pList {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			completedPods++
+		case corev1.PodFailed:
+			failedPod = pod
+		}
+	}
 
-	t.Status.Count = c.totalCompletedPods(trun)
+	t := trun.DeepCopy()
+	t.Status.Count = completedPods
 	t.Status.Message = t.Spec.Message
-	_, err = c.prunClient.AjV1alpha1().TaskRuns(trun.Namespace).UpdateStatus(context.Background(), t, metav1.UpdateOptions{})
+	t.Status.ObservedGeneration = trun.Generation
+	now := metav1.Now()
+	if t.Status.StartTime == nil {
+		t.Status.StartTime = &now
+	}
+
+	progress := fmt.Sprintf("%d/%d pods completed", completedPods, podCount(trun))
+	switch {
+	case trun.Spec.Cancelled:
+		setRunCondition(&t.Status.Conditions, trun.Generation, metav1.ConditionFalse, v1alpha1.ReasonCancelled,
+			fmt.Sprintf("TaskRun was cancelled, %s", progress))
+	case failedPod != nil:
+		msg := fmt.Sprintf("pod %s has failed", failedPod.Name)
+		if step := failedStep(failedPod); step != "" {
+			msg = fmt.Sprintf("%s, %s", msg, step)
+		}
+		setRunCondition(&t.Status.Conditions, trun.Generation, metav1.ConditionFalse, v1alpha1.ReasonFailed, msg)
+	case completedPods >= podCount(trun):
+		results, err := taskRunResults(trun, pList)
+		if err != nil {
+			setRunCondition(&t.Status.Conditions, trun.Generation, metav1.ConditionFalse, v1alpha1.ReasonFailed, err.Error())
+			break
+		}
+		t.Status.Results = results
+		setRunCondition(&t.Status.Conditions, trun.Generation, metav1.ConditionTrue, v1alpha1.ReasonSucceeded, progress)
+	case isTimedOut(trun):
+		setRunCondition(&t.Status.Conditions, trun.Generation, metav1.ConditionUnknown, v1alpha1.ReasonTimeout,
+			fmt.Sprintf("pods didn't complete within %v, %s", podTimeout, progress))
+	default:
+		setRunCondition(&t.Status.Conditions, trun.Generation, metav1.ConditionUnknown, v1alpha1.ReasonRunning, progress)
+	}
+	if isFinished(t.Status.Conditions) {
+		t.Status.CompletionTime = &now
+	}
 
-	return err
+	if equality.Semantic.DeepEqual(trun.Status, t.Status) {
+		return trun, nil
+	}
+
+	klog.V(4).Infof("updating status of TaskRun %s, %d/%d pods completed", trun.Name, completedPods, podCount(trun))
+
+	t, err = c.prunClient.AjV1alpha1().TaskRuns(trun.Namespace).UpdateStatus(context.Background(), t, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.recordFinished(trun, trun.Status.Conditions, t.Status.Conditions)
+	c.recordTimedOut(trun, trun.Status.Conditions, t.Status.Conditions)
+	return t, nil
 }
@@ -0,0 +1,51 @@
+package pipelinerun
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestPodsLabel(t *testing.T) {
+	long := strings.Repeat("pipeline", 8) + "-build-1"
+	tests := []struct {
+		name string
+		trun string
+		want string
+	}{
+		{name: "short name", trun: "run-build-1", want: "run-build-1"},
+		{name: "name of the maximum length", trun: strings.Repeat("a", 63), want: strings.Repeat("a", 63)},
+		{name: "long name", trun: long, want: long[:54] + "-"},
+		{name: "long name with a dot", trun: strings.Repeat("a", 53) + "." + long, want: strings.Repeat("a", 53) + ".-"},
+	}
+
+	seen := map[string]string{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := podsLabel(&v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: tt.trun}})
+			if errs := validation.IsValidLabelValue(got); len(errs) > 0 {
+				t.Fatalf("podsLabel() = %q, not a valid label value: %v", got, errs)
+			}
+			if len(tt.trun) <= validation.LabelValueMaxLength && got != tt.want {
+				t.Errorf("podsLabel() = %q, want %q", got, tt.want)
+			}
+			if len(tt.trun) > validation.LabelValueMaxLength && (len(got) != validation.LabelValueMaxLength || !strings.HasPrefix(got, tt.want)) {
+				t.Errorf("podsLabel() = %q, want %q suffixed with the hash of the name", got, tt.want)
+			}
+			if other, ok := seen[got]; ok {
+				t.Errorf("podsLabel() = %q, the same as for %q", got, other)
+			}
+			seen[got] = tt.trun
+		})
+	}
+
+	// TaskRuns of the same long prefix get their own labels.
+	a := podsLabel(&v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: long + "0"}})
+	b := podsLabel(&v1alpha1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: long + "1"}})
+	if a == b {
+		t.Errorf("podsLabel() = %q for both TaskRuns sharing a prefix", a)
+	}
+}
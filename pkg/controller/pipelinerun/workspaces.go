@@ -0,0 +1,261 @@
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/apoorvajagtap/trackPodCRD/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// workspaces are mounted at <workspacesDir>/<name>, unless the task
+	// asks for a mount path of its own.
+	workspacesDir = "/workspace"
+	// volumes of the workspaces are named <workspaceVolumePrefix><name>.
+	workspaceVolumePrefix = "ws-"
+)
+
+// $(workspaces.<name>.path) in the steps of a task
+var workspacePathRef = regexp.MustCompile(`\$\(workspaces\.([a-z0-9-]+)\.path\)`)
+
+// validates the workspaces declared by a task.
+func validateWorkspaces(workspaces []v1alpha1.WorkspaceDeclaration) error {
+	names := map[string]bool{}
+	paths := map[string]bool{}
+	for _, ws := range workspaces {
+		if errs := validation.IsDNS1123Label(workspaceVolumePrefix + ws.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid workspace name %q: %s", ws.Name, strings.Join(errs, ", "))
+		}
+		if names[ws.Name] {
+			return fmt.Errorf("workspace %q is declared more than once", ws.Name)
+		}
+		names[ws.Name] = true
+		if paths[mountPath(ws)] {
+			return fmt.Errorf("workspace %q is mounted at %s along with another workspace", ws.Name, mountPath(ws))
+		}
+		paths[mountPath(ws)] = true
+	}
+	return nil
+}
+
+// validates a workspace binding, it has to have exactly one volume source.
+func validateBinding(binding v1alpha1.WorkspaceBinding) error {
+	sources := 0
+	if binding.EmptyDir != nil {
+		sources++
+	}
+	if binding.PersistentVolumeClaim != nil {
+		sources++
+	}
+	if binding.ConfigMap != nil {
+		sources++
+	}
+	if binding.Secret != nil {
+		sources++
+	}
+	if binding.VolumeClaimTemplate != nil {
+		sources++
+	}
+	if sources != 1 {
+		return fmt.Errorf("workspace %q has to be bound to exactly one volume, it's bound to %d", binding.Name, sources)
+	}
+	return nil
+}
+
+// returns where the workspace is mounted in the steps
+func mountPath(ws v1alpha1.WorkspaceDeclaration) string {
+	if ws.MountPath != "" {
+		return ws.MountPath
+	}
+	return path.Join(workspacesDir, ws.Name)
+}
+
+// name of the PVC created off the volumeClaimTemplate of a workspace, for
+// current generation of the pipeline
+func claimName(prun *v1alpha1.PipelineRun, workspace string) string {
+	return fmt.Sprintf("%v-%v-%v", prun.Name, workspace, prun.ObjectMeta.Generation)
+}
+
+// resolveWorkspaces binds the workspaces of the tasks to the workspaces of
+// PipelineRun of the same name, unless the task binds them on its own. The
+// volumeClaimTemplate workspaces are bound to the PVC of the run. Fails on
+// invalid bindings & workspaces that are neither bound nor optional.
+func resolveWorkspaces(prun *v1alpha1.PipelineRun, tasks []v1alpha1.PipelineTask) ([]v1alpha1.PipelineTask, error) {
+	bindings := map[string]v1alpha1.WorkspaceBinding{}
+	for _, binding := range prun.Spec.Workspaces {
+		if _, ok := bindings[binding.Name]; ok {
+			return nil, fmt.Errorf("workspace %q is bound more than once", binding.Name)
+		}
+		if err := validateBinding(binding); err != nil {
+			return nil, err
+		}
+		if binding.VolumeClaimTemplate != nil {
+			binding = v1alpha1.WorkspaceBinding{
+				Name:    binding.Name,
+				SubPath: binding.SubPath,
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName(prun, binding.Name),
+				},
+			}
+		}
+		bindings[binding.Name] = binding
+	}
+
+	resolved := make([]v1alpha1.PipelineTask, 0, len(tasks))
+	for _, task := range tasks {
+		t := *task.DeepCopy()
+		bound := map[string]bool{}
+		for _, binding := range t.TaskRunSpec.WorkspaceBindings {
+			if err := validateBinding(binding); err != nil {
+				return nil, fmt.Errorf("task %q: %w", task.Name, err)
+			}
+			if binding.VolumeClaimTemplate != nil {
+				return nil, fmt.Errorf("task %q: workspace %q can only be bound to a volumeClaimTemplate by the PipelineRun", task.Name, binding.Name)
+			}
+			bound[binding.Name] = true
+		}
+		for _, ws := range t.TaskRunSpec.Workspaces {
+			if bound[ws.Name] {
+				continue
+			}
+			binding, ok := bindings[ws.Name]
+			if !ok {
+				if ws.Optional {
+					continue
+				}
+				return nil, fmt.Errorf("task %q: workspace %q isn't bound", task.Name, ws.Name)
+			}
+			t.TaskRunSpec.WorkspaceBindings = append(t.TaskRunSpec.WorkspaceBindings, *binding.DeepCopy())
+		}
+		resolved = append(resolved, t)
+	}
+	return resolved, nil
+}
+
+// replaces the $(workspaces.<name>.path) references in s, with the mount
+// path of the declared workspace. Path of an optional workspace that isn't
+// bound is empty.
+func substituteWorkspacePaths(s string, spec *v1alpha1.TaskRunSpec) (string, error) {
+	var err error
+	out := workspacePathRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := workspacePathRef.FindStringSubmatch(ref)[1]
+		for _, ws := range spec.Workspaces {
+			if ws.Name != name {
+				continue
+			}
+			if workspaceBinding(spec, name) == nil {
+				return ""
+			}
+			return mountPath(ws)
+		}
+		err = fmt.Errorf("unknown workspace %q is referred to in %q", name, s)
+		return ref
+	})
+	return out, err
+}
+
+// returns the binding of the workspace, nil if it isn't bound
+func workspaceBinding(spec *v1alpha1.TaskRunSpec, name string) *v1alpha1.WorkspaceBinding {
+	for i := range spec.WorkspaceBindings {
+		if spec.WorkspaceBindings[i].Name == name {
+			return &spec.WorkspaceBindings[i]
+		}
+	}
+	return nil
+}
+
+// adds the volumes of the bound workspaces of TaskRun to the pod, & mounts
+// them into all of its steps.
+func addWorkspaces(pod *corev1.Pod, spec *v1alpha1.TaskRunSpec) {
+	for _, ws := range spec.Workspaces {
+		binding := workspaceBinding(spec, ws.Name)
+		if binding == nil {
+			continue
+		}
+
+		volume := corev1.Volume{Name: workspaceVolumePrefix + ws.Name}
+		readOnly := ws.ReadOnly
+		switch {
+		case binding.EmptyDir != nil:
+			volume.EmptyDir = binding.EmptyDir.DeepCopy()
+		case binding.PersistentVolumeClaim != nil:
+			volume.PersistentVolumeClaim = binding.PersistentVolumeClaim.DeepCopy()
+		case binding.ConfigMap != nil:
+			volume.ConfigMap = binding.ConfigMap.DeepCopy()
+			readOnly = true
+		case binding.Secret != nil:
+			volume.Secret = binding.Secret.DeepCopy()
+			readOnly = true
+		}
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+
+		mount := corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: mountPath(ws),
+			SubPath:   binding.SubPath,
+			ReadOnly:  readOnly,
+		}
+		for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for i := range containers {
+				if strings.HasPrefix(containers[i].Name, stepContainerPrefix) {
+					containers[i].VolumeMounts = append(containers[i].VolumeMounts, mount)
+				}
+			}
+		}
+	}
+}
+
+// creates the PVCs of the volumeClaimTemplate workspaces of PipelineRun, for
+// the current run. The PVCs are owned by PipelineRun, & are deleted along
+// with it.
+func (c *Controller) createClaims(prun *v1alpha1.PipelineRun) error {
+	for _, binding := range prun.Spec.Workspaces {
+		if binding.VolumeClaimTemplate == nil {
+			continue
+		}
+
+		name := claimName(prun, binding.Name)
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   prun.Namespace,
+				Labels:      binding.VolumeClaimTemplate.Labels,
+				Annotations: binding.VolumeClaimTemplate.Annotations,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(prun, v1alpha1.SchemeGroupVersion.WithKind("PipelineRun")),
+				},
+			},
+			Spec: *binding.VolumeClaimTemplate.Spec.DeepCopy(),
+		}
+		_, err := c.kubeClient.CoreV1().PersistentVolumeClaims(prun.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{})
+		if errors.IsAlreadyExists(err) {
+			// PVC of an earlier reconcile, PVC of the same name not
+			// created for this PipelineRun isn't used.
+			existing, err := c.kubeClient.CoreV1().PersistentVolumeClaims(prun.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if !metav1.IsControlledBy(existing, prun) {
+				msg := fmt.Sprintf(MessageResourceExists, name)
+				c.recorder.Event(prun, corev1.EventTypeWarning, ErrResourceExists, msg)
+				return fmt.Errorf("%s", msg)
+			}
+			continue
+		}
+		if err != nil {
+			c.recorder.Eventf(prun, corev1.EventTypeWarning, FailedCreate, "Error creating PersistentVolumeClaim %s: %v", name, err)
+			return err
+		}
+		klog.Infof("PersistentVolumeClaim %s has been created for PipelineRun %s", name, prun.Name)
+		c.recorder.Eventf(prun, corev1.EventTypeNormal, SuccessfulCreate, "Created PersistentVolumeClaim: %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package trackpod
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// selector of the pods managed by TrackPod, defaults to the controller label
+// set on every pod created for it.
+func podSelector(tpod *v1.TrackPod) (labels.Selector, error) {
+	if tpod.Spec.Selector == nil {
+		return labels.SelectorFromSet(labels.Set{
+			"controller": tpod.Name,
+		}), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(tpod.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of TrackPod %s: %w", tpod.Name, err)
+	}
+	// an empty selector would match (& adopt) every pod of the namespace.
+	if selector.Empty() {
+		return nil, fmt.Errorf("empty selector of TrackPod %s isn't allowed", tpod.Name)
+	}
+	return selector, nil
+}
+
+// claimPods returns the pods controlled by TrackPod, after adopting the
+// orphan pods matching its selector & releasing the owned ones that don't
+// match it anymore. Pods controlled by anyone else are never touched.
+func (c *Controller) claimPods(tpod *v1.TrackPod) ([]*corev1.Pod, error) {
+	selector, err := podSelector(tpod)
+	if err != nil {
+		return nil, err
+	}
+	pList, err := c.podLister.Pods(tpod.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	// TrackPod is only checked against the API once, & only if there's an
+	// orphan to be adopted.
+	var canAdopt *bool
+	var claimed []*corev1.Pod
+	for _, pod := range pList {
+		ref := metav1.GetControllerOf(pod)
+		if ref != nil {
+			if ref.UID != tpod.UID {
+				continue
+			}
+			if selector.Matches(labels.Set(pod.Labels)) {
+				claimed = append(claimed, pod)
+				continue
+			}
+			// deleted TrackPod keeps its pods, to drain them.
+			if !tpod.DeletionTimestamp.IsZero() {
+				claimed = append(claimed, pod)
+				continue
+			}
+			if err := c.releasePod(tpod, pod); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if !pod.DeletionTimestamp.IsZero() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if canAdopt == nil {
+			ok, err := c.canAdopt(tpod)
+			if err != nil {
+				return nil, err
+			}
+			canAdopt = &ok
+		}
+		if !*canAdopt {
+			continue
+		}
+		if err := c.adoptPod(tpod, pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		claimed = append(claimed, pod)
+	}
+
+	return claimed, nil
+}
+
+// cached TrackPod might be stale, it's fetched again to make sure it's
+// neither deleted nor recreated before adopting any pods.
+func (c *Controller) canAdopt(tpod *v1.TrackPod) (bool, error) {
+	fresh, err := c.tpodClient.AjV1().TrackPods(tpod.Namespace).Get(context.Background(), tpod.Name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return fresh.UID == tpod.UID && fresh.DeletionTimestamp.IsZero(), nil
+}
+
+// sets TrackPod as the controller of the orphan pod
+func (c *Controller) adoptPod(tpod *v1.TrackPod, pod *corev1.Pod) error {
+	ref := metav1.NewControllerRef(tpod, v1.SchemeGroupVersion.WithKind("TrackPod"))
+	patch := fmt.Sprintf(`{"metadata":{"ownerReferences":[{"apiVersion":%q,"kind":%q,"name":%q,"uid":%q,"controller":true,"blockOwnerDeletion":true}],"uid":%q}}`,
+		ref.APIVersion, ref.Kind, ref.Name, ref.UID, pod.UID)
+	_, err := c.kubeClient.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+	klog.Infof("TrackPod %s adopted the pod %s", tpod.Name, pod.Name)
+	c.recorder.Eventf(tpod, corev1.EventTypeNormal, AdoptedPod, "Adopted pod: %s", pod.Name)
+	return nil
+}
+
+// removes TrackPod from the owner references of the pod
+func (c *Controller) releasePod(tpod *v1.TrackPod, pod *corev1.Pod) error {
+	patch := fmt.Sprintf(`{"metadata":{"ownerReferences":[{"$patch":"delete","uid":%q}],"uid":%q}}`, tpod.UID, pod.UID)
+	_, err := c.kubeClient.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		// pod is already gone, or its uid has changed (i.e. it's recreated).
+		if errors.IsNotFound(err) || errors.IsInvalid(err) {
+			return nil
+		}
+		return err
+	}
+	klog.Infof("TrackPod %s released the pod %s", tpod.Name, pod.Name)
+	c.recorder.Eventf(tpod, corev1.EventTypeNormal, ReleasedPod, "Released pod: %s", pod.Name)
+	return nil
+}
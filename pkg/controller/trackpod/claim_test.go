@@ -0,0 +1,210 @@
+package trackpod
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// pod of TrackPod with its owner reference dropped, i.e. an orphan.
+func orphanOf(tpod *v1.TrackPod, name string) *corev1.Pod {
+	pod := podOf(tpod, testPod(name, true, 0))
+	pod.OwnerReferences = nil
+	return pod
+}
+
+// pod of TrackPod labelled to be selected by another TrackPod.
+func relabelled(pod *corev1.Pod) *corev1.Pod {
+	pod.Labels["controller"] = "other"
+	return pod
+}
+
+// pod of TrackPod, controlled by another controller instead.
+func controlledByOther(pod *corev1.Pod) *corev1.Pod {
+	pod.OwnerReferences[0].Name = "other"
+	pod.OwnerReferences[0].UID = types.UID("other-uid")
+	return pod
+}
+
+func deletingPod(pod *corev1.Pod) *corev1.Pod {
+	now := metav1.Now()
+	pod.DeletionTimestamp = &now
+	return pod
+}
+
+func deletingTrackPod(tpod *v1.TrackPod) *v1.TrackPod {
+	now := metav1.Now()
+	tpod.DeletionTimestamp = &now
+	return tpod
+}
+
+func TestClaimPods(t *testing.T) {
+	tests := []struct {
+		name string
+		tpod *v1.TrackPod
+		// TrackPod as it's got from the API, if not the same as tpod
+		fresh        *v1.TrackPod
+		pods         func(tpod *v1.TrackPod) []*corev1.Pod
+		wantClaimed  []string
+		wantAdopted  []string
+		wantReleased []string
+	}{
+		{
+			name: "owned pods matching the selector",
+			tpod: testTrackPod("tpod", 2),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{podOf(tpod, testPod("a", true, 0)), podOf(tpod, testPod("b", true, 1))}
+			},
+			wantClaimed:  []string{"a", "b"},
+			wantAdopted:  []string{},
+			wantReleased: []string{},
+		},
+		{
+			name: "orphan matching the selector is adopted",
+			tpod: testTrackPod("tpod", 2),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{podOf(tpod, testPod("owned", true, 0)), orphanOf(tpod, "orphan")}
+			},
+			wantClaimed:  []string{"orphan", "owned"},
+			wantAdopted:  []string{"orphan"},
+			wantReleased: []string{},
+		},
+		{
+			name: "orphan not matching the selector is left alone",
+			tpod: testTrackPod("tpod", 1),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{relabelled(orphanOf(tpod, "orphan"))}
+			},
+			wantClaimed:  []string{},
+			wantAdopted:  []string{},
+			wantReleased: []string{},
+		},
+		{
+			name: "orphan being deleted isn't adopted",
+			tpod: testTrackPod("tpod", 1),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{deletingPod(orphanOf(tpod, "orphan"))}
+			},
+			wantClaimed:  []string{},
+			wantAdopted:  []string{},
+			wantReleased: []string{},
+		},
+		{
+			name: "owned pod not matching the selector is released",
+			tpod: testTrackPod("tpod", 1),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{podOf(tpod, testPod("owned", true, 0)), relabelled(podOf(tpod, testPod("relabelled", true, 1)))}
+			},
+			wantClaimed:  []string{"owned"},
+			wantAdopted:  []string{},
+			wantReleased: []string{"relabelled"},
+		},
+		{
+			name: "pod of another controller is left alone",
+			tpod: testTrackPod("tpod", 1),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{controlledByOther(podOf(tpod, testPod("other", true, 0))), controlledByOther(relabelled(podOf(tpod, testPod("other-relabelled", true, 0))))}
+			},
+			wantClaimed:  []string{},
+			wantAdopted:  []string{},
+			wantReleased: []string{},
+		},
+		{
+			name: "TrackPod being deleted adopts nothing & keeps its pods",
+			tpod: deletingTrackPod(testTrackPod("tpod", 1)),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{orphanOf(tpod, "orphan"), relabelled(podOf(tpod, testPod("relabelled", true, 0)))}
+			},
+			wantClaimed:  []string{"relabelled"},
+			wantAdopted:  []string{},
+			wantReleased: []string{},
+		},
+		{
+			name:  "TrackPod deleted since it was cached adopts nothing",
+			tpod:  testTrackPod("tpod", 1),
+			fresh: deletingTrackPod(testTrackPod("tpod", 1)),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{orphanOf(tpod, "orphan")}
+			},
+			wantClaimed:  []string{},
+			wantAdopted:  []string{},
+			wantReleased: []string{},
+		},
+		{
+			name: "TrackPod recreated since it was cached adopts nothing",
+			tpod: testTrackPod("tpod", 1),
+			fresh: func() *v1.TrackPod {
+				tpod := testTrackPod("tpod", 1)
+				tpod.UID = "recreated-uid"
+				return tpod
+			}(),
+			pods: func(tpod *v1.TrackPod) []*corev1.Pod {
+				return []*corev1.Pod{orphanOf(tpod, "orphan")}
+			},
+			wantClaimed:  []string{},
+			wantAdopted:  []string{},
+			wantReleased: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []runtime.Object{tt.tpod}
+			for _, pod := range tt.pods(tt.tpod) {
+				objects = append(objects, pod)
+			}
+			f := newFixture(t, objects...)
+			if tt.fresh != nil {
+				if err := f.tpods.Tracker().Update(v1.SchemeGroupVersion.WithResource("trackpods"), tt.fresh, tt.fresh.Namespace); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			claimed, err := f.c.claimPods(tt.tpod)
+			if err != nil {
+				t.Fatalf("claimPods() unexpected error: %v", err)
+			}
+			got := podNames(claimed)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.wantClaimed) {
+				t.Errorf("claimPods() = %v, want %v", got, tt.wantClaimed)
+			}
+
+			// every patch either adopts or releases, as per the controller
+			// of the patched pod.
+			adopted, released := []string{}, []string{}
+			for _, name := range f.actions("patch", "pods") {
+				pod, err := f.kube.CoreV1().Pods(tt.tpod.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if metav1.IsControlledBy(pod, tt.tpod) {
+					adopted = append(adopted, name)
+				} else if len(pod.OwnerReferences) == 0 {
+					released = append(released, name)
+				} else {
+					t.Errorf("claimPods() patches pod %s into %+v", name, pod.OwnerReferences)
+				}
+			}
+			if !reflect.DeepEqual(adopted, tt.wantAdopted) {
+				t.Errorf("claimPods() adopts %v, want %v", adopted, tt.wantAdopted)
+			}
+			if !reflect.DeepEqual(released, tt.wantReleased) {
+				t.Errorf("claimPods() releases %v, want %v", released, tt.wantReleased)
+			}
+			if events := f.events(AdoptedPod); len(events) != len(tt.wantAdopted) {
+				t.Errorf("claimPods() records %v, want an event per adopted pod", events)
+			}
+			if events := f.events(ReleasedPod); len(events) != len(tt.wantReleased) {
+				t.Errorf("claimPods() records %v, want an event per released pod", events)
+			}
+		})
+	}
+}
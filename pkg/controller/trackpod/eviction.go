@@ -0,0 +1,103 @@
+package trackpod
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// blocked evictions are retried after evictionRetryInterval, unless the API
+// server suggests a delay of its own.
+const evictionRetryInterval = 10 * time.Second
+
+// evictionBlockedError is returned by the sync when some of the pods couldn't
+// be evicted (429), as a PodDisruptionBudget won't allow it.
+type evictionBlockedError struct {
+	pods  []string
+	delay time.Duration
+}
+
+func (e *evictionBlockedError) Error() string {
+	return fmt.Sprintf("eviction of pods %s is blocked by a PodDisruptionBudget", strings.Join(e.pods, ", "))
+}
+
+// returns the evictionBlockedError err is (or wraps), nil otherwise
+func evictionBlocked(err error) *evictionBlockedError {
+	var blocked *evictionBlockedError
+	if goerrors.As(err, &blocked) {
+		return blocked
+	}
+	return nil
+}
+
+// removes the pods of TrackPod as per its scaleDownPolicy, either by deleting
+// or evicting them.
+func (c *Controller) removePods(tpod *v1.TrackPod, pods []*corev1.Pod) error {
+	if tpod.Spec.ScaleDownPolicy == v1.EvictScaleDownPolicy {
+		return c.evictPods(tpod, pods)
+	}
+	return c.deletePods(tpod, pods)
+}
+
+// evicts the pods through the Eviction API. Pods the disruption budgets won't
+// let go are skipped, & reported with an evictionBlockedError once the rest
+// of the pods are evicted.
+func (c *Controller) evictPods(tpod *v1.TrackPod, pods []*corev1.Pod) error {
+	if len(pods) == 0 {
+		return nil
+	}
+	key, err := cache.MetaNamespaceKeyFunc(tpod)
+	if err != nil {
+		return err
+	}
+	podKeys := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podKeys = append(podKeys, podKey(pod))
+	}
+	c.expectations.expectDeletions(key, podKeys)
+
+	blocked := &evictionBlockedError{delay: evictionRetryInterval}
+	for i, pod := range pods {
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		}
+		err := c.kubeClient.CoreV1().Pods(tpod.Namespace).EvictV1(context.TODO(), eviction)
+		switch {
+		case err == nil:
+			c.recorder.Eventf(tpod, corev1.EventTypeNormal, SuccessfulEvict, "Evicted pod: %s", pod.Name)
+		case errors.IsNotFound(err):
+			// pod that's already gone isn't waited for.
+			c.expectations.deletionObserved(key, podKey(pod))
+		case errors.IsTooManyRequests(err):
+			// pod stays as is, it's evicted by a later sync.
+			c.expectations.deletionObserved(key, podKey(pod))
+			blocked.pods = append(blocked.pods, pod.Name)
+			if delay, ok := errors.SuggestsClientDelay(err); ok && time.Duration(delay)*time.Second > blocked.delay {
+				blocked.delay = time.Duration(delay) * time.Second
+			}
+			c.recorder.Eventf(tpod, corev1.EventTypeWarning, EvictionBlocked, "Eviction of pod %s is blocked: %v", pod.Name, err)
+		default:
+			// pods left out after a failure aren't waited for either.
+			for _, pod := range pods[i:] {
+				c.expectations.deletionObserved(key, podKey(pod))
+			}
+			klog.Errorf("Pod eviction failed for CR %v\n", tpod.Name)
+			c.recorder.Eventf(tpod, corev1.EventTypeWarning, FailedEvict, "Error evicting pod %s: %v", pod.Name, err)
+			return err
+		}
+	}
+	if len(blocked.pods) > 0 {
+		return blocked
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package trackpod
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+)
+
+// reactor answering the evictions of the pods of blocked with a 429, as a
+// PodDisruptionBudget does, suggesting retryAfter seconds. Other evictions
+// succeed.
+func blockEvictions(retryAfter int, blocked ...string) core.ReactionFunc {
+	return func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		name := action.(core.CreateAction).GetObject().(metav1.Object).GetName()
+		for _, pod := range blocked {
+			if pod == name {
+				return true, nil, errors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", retryAfter)
+			}
+		}
+		return true, nil, nil
+	}
+}
+
+func TestEvictionBlocked(t *testing.T) {
+	tests := []struct {
+		name        string
+		blocked     []string
+		retryAfter  int
+		wantEvicted int
+		wantRequeue time.Duration
+	}{
+		{
+			name:        "nothing blocked",
+			wantEvicted: 2,
+		},
+		{
+			name:        "all evictions blocked",
+			blocked:     []string{"b", "c"},
+			wantRequeue: evictionRetryInterval,
+		},
+		{
+			name:        "some evictions blocked",
+			blocked:     []string{"c"},
+			wantEvicted: 1,
+			wantRequeue: evictionRetryInterval,
+		},
+		{
+			name:        "delay suggested by the API server",
+			blocked:     []string{"b", "c"},
+			retryAfter:  30,
+			wantRequeue: 30 * time.Second,
+		},
+		{
+			name:        "suggested delay shorter than the retry interval",
+			blocked:     []string{"c"},
+			retryAfter:  1,
+			wantEvicted: 1,
+			wantRequeue: evictionRetryInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpod := testTrackPod("tpod", 1)
+			tpod.Spec.ScaleDownPolicy = v1.EvictScaleDownPolicy
+			// b & c are the newest, removed first.
+			f := newFixture(t, tpod, podOf(tpod, testPod("a", true, 0)), podOf(tpod, testPod("b", true, 1)), podOf(tpod, testPod("c", true, 2)))
+			f.kube.PrependReactor("create", "pods", blockEvictions(tt.retryAfter, tt.blocked...))
+			key := keyOf(t, tpod)
+
+			if _, err := f.c.reconcile(key); err != nil {
+				t.Fatalf("reconcile() unexpected error: %v", err)
+			}
+			if got := f.actions("create", "pods/eviction"); len(got) != 2 {
+				t.Errorf("reconcile() evicts %v, want the 2 newest pods", got)
+			}
+			if events := f.events(SuccessfulEvict); len(events) != tt.wantEvicted {
+				t.Errorf("reconcile() records %v, want %d evictions", events, tt.wantEvicted)
+			}
+			if events := f.events(EvictionBlocked); len(events) != len(tt.blocked) {
+				t.Errorf("reconcile() records %v, want an event per blocked pod", events)
+			}
+			if events := f.events(FailedSync); len(events) != 0 {
+				t.Errorf("reconcile() records %v, blocked evictions aren't a failure", events)
+			}
+			if got, ok := f.queue.after[key]; tt.wantRequeue > 0 && got != tt.wantRequeue || tt.wantRequeue == 0 && ok {
+				t.Errorf("reconcile() requeues after %v, want %v", got, tt.wantRequeue)
+			}
+
+			got, err := f.tpods.AjV1().TrackPods(tpod.Namespace).Get(context.Background(), tpod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			blocked := append([]string{}, got.Status.BlockedEvictions...)
+			sort.Strings(blocked)
+			if want := append([]string{}, tt.blocked...); !reflect.DeepEqual(blocked, want) {
+				t.Errorf("reconcile() reports blocked evictions %v, want %v", blocked, want)
+			}
+			if meta.IsStatusConditionTrue(got.Status.Conditions, v1.TrackPodDegraded) {
+				t.Errorf("reconcile() sets Degraded to %+v, blocked evictions don't degrade", meta.FindStatusCondition(got.Status.Conditions, v1.TrackPodDegraded))
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package trackpod
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// expectationsTimeout is how long the expectations of a TrackPod are waited
+// for, pods created/deleted in the meantime might never be observed (e.g. if
+// the watch has been dropped).
+const expectationsTimeout = 5 * time.Minute
+
+// expectation of a single TrackPod: the number of creates & the pods
+// (namespace/name keys) of the deletes that are yet to be observed.
+type expectation struct {
+	add       int
+	del       map[string]struct{}
+	timestamp time.Time
+}
+
+func (e *expectation) fulfilled() bool {
+	return e.add <= 0 && len(e.del) == 0
+}
+
+// expectations track the pods a TrackPod has created/deleted, that are yet
+// to show up in (or leave) the pod cache. The TrackPod isn't synced until
+// its expectations are fulfilled, as it would act on a stale cache & create
+// or delete the same pods again.
+type expectations struct {
+	sync.Mutex
+	store map[string]*expectation
+}
+
+func newExpectations() *expectations {
+	return &expectations{store: map[string]*expectation{}}
+}
+
+// satisfied tells whether TrackPod of key can be synced, i.e. it doesn't
+// expect anything or its expectations are fulfilled (or expired).
+func (e *expectations) satisfied(key string) bool {
+	e.Lock()
+	defer e.Unlock()
+
+	exp, ok := e.store[key]
+	if !ok || exp.fulfilled() {
+		return true
+	}
+	if time.Since(exp.timestamp) > expectationsTimeout {
+		klog.V(4).Infof("expectations of TrackPod %q have expired, %d creates & %d deletes weren't observed", key, exp.add, len(exp.del))
+		return true
+	}
+	return false
+}
+
+// expectCreations records that count pods are about to be created.
+func (e *expectations) expectCreations(key string, count int) {
+	e.Lock()
+	defer e.Unlock()
+	e.get(key).add = count
+}
+
+// expectDeletions records that the pods of podKeys are about to be deleted.
+func (e *expectations) expectDeletions(key string, podKeys []string) {
+	e.Lock()
+	defer e.Unlock()
+	exp := e.get(key)
+	for _, podKey := range podKeys {
+		exp.del[podKey] = struct{}{}
+	}
+}
+
+// creationObserved lowers the creates expected by TrackPod of key, it's
+// called for a new pod showing up in the cache & for a failed create.
+func (e *expectations) creationObserved(key string) {
+	e.Lock()
+	defer e.Unlock()
+	if exp, ok := e.store[key]; ok && exp.add > 0 {
+		exp.add--
+	}
+}
+
+// deletionObserved drops the pod from the deletes expected by TrackPod of
+// key, it's called for a pod being deleted & for a failed delete.
+func (e *expectations) deletionObserved(key, podKey string) {
+	e.Lock()
+	defer e.Unlock()
+	if exp, ok := e.store[key]; ok {
+		delete(exp.del, podKey)
+	}
+}
+
+// delete forgets the expectations of a deleted TrackPod.
+func (e *expectations) delete(key string) {
+	e.Lock()
+	defer e.Unlock()
+	delete(e.store, key)
+}
+
+// returns the (reset, if fulfilled or expired) expectation of key, the lock
+// has to be held by the caller.
+func (e *expectations) get(key string) *expectation {
+	exp, ok := e.store[key]
+	if !ok || exp.fulfilled() || time.Since(exp.timestamp) > expectationsTimeout {
+		exp = &expectation{del: map[string]struct{}{}}
+		e.store[key] = exp
+	}
+	exp.timestamp = time.Now()
+	return exp
+}
@@ -0,0 +1,127 @@
+package trackpod
+
+import (
+	"testing"
+	"time"
+)
+
+const testKey = "default/tpod"
+
+func TestExpectations(t *testing.T) {
+	tests := []struct {
+		name string
+		// steps run against the expectations of testKey, in order
+		steps []func(e *expectations)
+		want  bool
+	}{
+		{
+			name: "nothing expected",
+			want: true,
+		},
+		{
+			name:  "creations pending",
+			steps: []func(e *expectations){expectCreations(2), creationObserved},
+			want:  false,
+		},
+		{
+			name:  "creations observed",
+			steps: []func(e *expectations){expectCreations(2), creationObserved, creationObserved},
+			want:  true,
+		},
+		{
+			name: "extra creations observed don't go below 0",
+			steps: []func(e *expectations){
+				expectCreations(1), creationObserved, creationObserved, creationObserved,
+				// the new expectation isn't lowered by the creates seen before it.
+				expectCreations(1),
+			},
+			want: false,
+		},
+		{
+			name:  "creations observed before any are expected",
+			steps: []func(e *expectations){creationObserved, expectCreations(1)},
+			want:  false,
+		},
+		{
+			name:  "deletions pending",
+			steps: []func(e *expectations){expectDeletions("ns/a", "ns/b"), deletionObserved("ns/a")},
+			want:  false,
+		},
+		{
+			name:  "deletions observed",
+			steps: []func(e *expectations){expectDeletions("ns/a", "ns/b"), deletionObserved("ns/b"), deletionObserved("ns/a")},
+			want:  true,
+		},
+		{
+			name:  "deletion of another pod observed",
+			steps: []func(e *expectations){expectDeletions("ns/a"), deletionObserved("ns/other")},
+			want:  false,
+		},
+		{
+			name:  "creations & deletions both have to be observed",
+			steps: []func(e *expectations){expectCreations(1), expectDeletions("ns/a"), creationObserved},
+			want:  false,
+		},
+		{
+			name:  "expired expectations",
+			steps: []func(e *expectations){expectCreations(3), expire},
+			want:  true,
+		},
+		{
+			name:  "expired expectations are reset when expecting again",
+			steps: []func(e *expectations){expectDeletions("ns/a"), expire, expectCreations(1), creationObserved},
+			want:  true,
+		},
+		{
+			name:  "deleted TrackPod",
+			steps: []func(e *expectations){expectCreations(1), expectDeletions("ns/a"), deleteKey},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newExpectations()
+			for _, step := range tt.steps {
+				step(e)
+			}
+			if got := e.satisfied(testKey); got != tt.want {
+				t.Errorf("satisfied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func expectCreations(count int) func(e *expectations) {
+	return func(e *expectations) { e.expectCreations(testKey, count) }
+}
+
+func creationObserved(e *expectations) { e.creationObserved(testKey) }
+
+func expectDeletions(podKeys ...string) func(e *expectations) {
+	return func(e *expectations) { e.expectDeletions(testKey, podKeys) }
+}
+
+func deletionObserved(podKey string) func(e *expectations) {
+	return func(e *expectations) { e.deletionObserved(testKey, podKey) }
+}
+
+func deleteKey(e *expectations) { e.delete(testKey) }
+
+// moves the expectation of testKey past expectationsTimeout.
+func expire(e *expectations) {
+	e.store[testKey].timestamp = time.Now().Add(-expectationsTimeout - time.Second)
+}
+
+func TestExpectationsOfOtherKeys(t *testing.T) {
+	e := newExpectations()
+	e.expectCreations(testKey, 1)
+	e.creationObserved("default/other")
+	e.deletionObserved("default/other", "ns/a")
+	if e.satisfied(testKey) {
+		t.Errorf("satisfied() = true after observing the pods of another TrackPod")
+	}
+	if !e.satisfied("default/other") {
+		t.Errorf("satisfied() = false for a TrackPod without expectations")
+	}
+}
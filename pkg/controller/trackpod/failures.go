@@ -0,0 +1,184 @@
+package trackpod
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// failed pods of a TrackPod are replaced at once the first time, the
+	// following failures back off exponentially up to failedPodsBackoffMax.
+	failedPodsBackoffInitial = time.Second
+	failedPodsBackoffMax     = 15 * time.Minute
+
+	// pods listed in the message of the Degraded condition are bounded by
+	// maxDegradedPods.
+	maxDegradedPods = 5
+)
+
+// waiting reasons of the containers that won't recover on their own
+var failingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// failureBackoff backs off replacing the failed pods of the TrackPods, per
+// TrackPod key. The backoff is counted from the last replacement.
+type failureBackoff struct {
+	backoff *flowcontrol.Backoff
+	mu      sync.Mutex
+	// time the failed pods were last replaced, per TrackPod key.
+	replaced map[string]time.Time
+}
+
+func newFailureBackoff() *failureBackoff {
+	return &failureBackoff{
+		backoff:  flowcontrol.NewBackOff(failedPodsBackoffInitial, failedPodsBackoffMax),
+		replaced: map[string]time.Time{},
+	}
+}
+
+// remaining returns the time left of the backoff of key, 0 if it isn't
+// backing off.
+func (b *failureBackoff) remaining(key string, now time.Time) time.Duration {
+	if !b.backoff.IsInBackOffSinceUpdate(key, now) {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	left := b.backoff.Get(key) - now.Sub(b.replaced[key])
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// next records the failed pods of key being replaced at now, & backs off
+// the next replacement further.
+func (b *failureBackoff) next(key string, now time.Time) {
+	b.backoff.Next(key, now)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.replaced[key] = now
+}
+
+// delete forgets the backoff of a deleted TrackPod.
+func (b *failureBackoff) delete(key string) {
+	b.backoff.DeleteEntry(key)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.replaced, key)
+}
+
+// gc drops the backoffs that have expired, i.e. no pods have failed for
+// twice the max backoff.
+func (b *failureBackoff) gc() {
+	b.backoff.GC()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.backoff.Clock.Now()
+	for key, replaced := range b.replaced {
+		if now.Sub(replaced) > 2*failedPodsBackoffMax {
+			delete(b.replaced, key)
+		}
+	}
+}
+
+// failed pods, that aren't being deleted yet
+func failedPods(pList []*corev1.Pod) []*corev1.Pod {
+	var failed []*corev1.Pod
+	for _, pod := range pList {
+		if pod.DeletionTimestamp.IsZero() && pod.Status.Phase == corev1.PodFailed {
+			failed = append(failed, pod)
+		}
+	}
+	return failed
+}
+
+// handleFailedPods deletes the failed pods of TrackPod, for them to be
+// replaced by the sync. Repeated failures are backed off exponentially, the
+// pods are left as is until the backoff is over. Returns whether TrackPod is
+// in backoff, i.e. no replacements are to be created now.
+func (c *Controller) handleFailedPods(tpod *v1.TrackPod, pList []*corev1.Pod) (bool, error) {
+	failed := failedPods(pList)
+	if len(failed) == 0 {
+		return false, nil
+	}
+	key, err := cache.MetaNamespaceKeyFunc(tpod)
+	if err != nil {
+		return false, err
+	}
+
+	// the key is requeued for the rest of the backoff only, the reconciles
+	// in the meantime don't push it any further.
+	now := c.failedPodsBackoff.backoff.Clock.Now()
+	if delay := c.failedPodsBackoff.remaining(key, now); delay > 0 {
+		klog.V(4).Infof("%d pods of TrackPod %q have failed, backing off for %v", len(failed), key, delay)
+		c.wq.AddAfter(key, delay)
+		return true, nil
+	}
+
+	c.failedPodsBackoff.next(key, now)
+	for _, pod := range failed {
+		c.recorder.Eventf(tpod, corev1.EventTypeWarning, FailedPod, "Pod %s has failed (%s), replacing it", pod.Name, podFailure(pod))
+	}
+	return false, c.deletePods(tpod, failed)
+}
+
+// reason the pod has failed with
+func podFailure(pod *corev1.Pod) string {
+	if pod.Status.Reason != "" {
+		return pod.Status.Reason
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if reason, _ := containerFailure(status); reason != "" {
+			return reason
+		}
+	}
+	return string(corev1.PodFailed)
+}
+
+// pods that are failed or have containers failing to run (e.g. crash looping),
+// in the form of "<pod> (<reason>)", sorted so that the Degraded message
+// doesn't change with the order of the lister.
+func degradedPods(pList []*corev1.Pod) []string {
+	var degraded []string
+	for _, pod := range pList {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodFailed {
+			degraded = append(degraded, fmt.Sprintf("%s (%s)", pod.Name, podFailure(pod)))
+			continue
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if w := status.State.Waiting; w != nil && failingReasons[w.Reason] {
+				degraded = append(degraded, fmt.Sprintf("%s/%s (%s)", pod.Name, status.Name, w.Reason))
+			}
+		}
+	}
+	sort.Strings(degraded)
+	return degraded
+}
+
+// message of the Degraded condition, listing at most maxDegradedPods pods
+func degradedMessage(degraded []string) string {
+	if len(degraded) <= maxDegradedPods {
+		return "failing pods: " + strings.Join(degraded, ", ")
+	}
+	return fmt.Sprintf("failing pods: %s and %d more", strings.Join(degraded[:maxDegradedPods], ", "), len(degraded)-maxDegradedPods)
+}
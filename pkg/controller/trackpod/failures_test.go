@@ -0,0 +1,187 @@
+package trackpod
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func crashLooping(pod *corev1.Pod) {
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "main",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+	}}
+}
+
+func TestHandleFailedPods(t *testing.T) {
+	tpod := testTrackPod("tpod", 2)
+	failed := podOf(tpod, withPod(testPod("failed", false, 0), phase(corev1.PodFailed)))
+	running := podOf(tpod, testPod("running", true, 1))
+	f := newFixture(t, tpod, failed, running)
+	key := keyOf(t, tpod)
+
+	// the same pod keeps failing, as if every replacement failed again.
+	steps := []struct {
+		name        string
+		pods        []*corev1.Pod
+		elapsed     time.Duration
+		wantBackoff bool
+		wantDeleted []string
+		wantRequeue time.Duration
+	}{
+		{
+			name:        "no failed pods",
+			pods:        []*corev1.Pod{running},
+			wantDeleted: []string{},
+		},
+		{
+			name:        "first failure is replaced at once",
+			pods:        []*corev1.Pod{failed, running},
+			wantDeleted: []string{"failed"},
+		},
+		{
+			name:        "failing again backs off",
+			pods:        []*corev1.Pod{failed, running},
+			wantBackoff: true,
+			wantDeleted: []string{},
+			wantRequeue: failedPodsBackoffInitial,
+		},
+		{
+			name:        "requeued for the time left of the backoff",
+			pods:        []*corev1.Pod{failed, running},
+			elapsed:     400 * time.Millisecond,
+			wantBackoff: true,
+			wantDeleted: []string{},
+			wantRequeue: 600 * time.Millisecond,
+		},
+		{
+			name:        "replaced once the backoff is over",
+			pods:        []*corev1.Pod{failed, running},
+			elapsed:     600 * time.Millisecond,
+			wantDeleted: []string{"failed"},
+		},
+		{
+			name:        "backoff doubles",
+			pods:        []*corev1.Pod{failed, running},
+			wantBackoff: true,
+			wantDeleted: []string{},
+			wantRequeue: 2 * failedPodsBackoffInitial,
+		},
+		{
+			name:        "backoff is capped",
+			pods:        []*corev1.Pod{failed, running},
+			elapsed:     time.Hour,
+			wantDeleted: []string{"failed"},
+		},
+	}
+
+	for _, step := range steps {
+		f.clock.Step(step.elapsed)
+		f.kube.ClearActions()
+		delete(f.queue.after, key)
+
+		backoff, err := f.c.handleFailedPods(tpod, step.pods)
+		if err != nil {
+			t.Fatalf("%s: handleFailedPods() unexpected error: %v", step.name, err)
+		}
+		if backoff != step.wantBackoff {
+			t.Errorf("%s: handleFailedPods() = %v, want %v", step.name, backoff, step.wantBackoff)
+		}
+		if got := f.actions("delete", "pods"); !reflect.DeepEqual(got, step.wantDeleted) {
+			t.Errorf("%s: handleFailedPods() deletes %v, want %v", step.name, got, step.wantDeleted)
+		}
+		if events := f.events(FailedPod); len(events) != len(step.wantDeleted) {
+			t.Errorf("%s: handleFailedPods() records %v, want an event per deleted pod", step.name, events)
+		}
+		if got, ok := f.queue.after[key]; step.wantRequeue > 0 && got != step.wantRequeue || step.wantRequeue == 0 && ok {
+			t.Errorf("%s: handleFailedPods() requeues after %v, want %v", step.name, got, step.wantRequeue)
+		}
+		// the deleted pod is back, failing again.
+		if err := f.kube.Tracker().Add(failed); err != nil && !strings.Contains(err.Error(), "already exists") {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUpdateStatusFailures(t *testing.T) {
+	tests := []struct {
+		name         string
+		failing      int
+		wantReasons  []string
+		wantDegraded string
+	}{
+		{
+			name: "no failing pods",
+		},
+		{
+			name:         "few failing pods",
+			failing:      2,
+			wantReasons:  []string{"pod-1", "pod-0"},
+			wantDegraded: "failing pods: pod-0/main (CrashLoopBackOff), pod-1/main (CrashLoopBackOff)",
+		},
+		{
+			name:    "failure reasons are capped",
+			failing: 12,
+			// most recent pods first.
+			wantReasons:  []string{"pod-11", "pod-10", "pod-9", "pod-8", "pod-7", "pod-6", "pod-5", "pod-4", "pod-3", "pod-2"},
+			wantDegraded: "and 7 more",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpod := testTrackPod("tpod", 12)
+			objects := []runtime.Object{tpod}
+			for i := 0; i < 12; i++ {
+				pod := testPod(fmt.Sprintf("pod-%d", i), i >= tt.failing, i)
+				if i < tt.failing {
+					crashLooping(pod)
+				}
+				objects = append(objects, podOf(tpod, pod))
+			}
+			f := newFixture(t, objects...)
+
+			if err := f.c.updateStatus(tpod, nil); err != nil {
+				t.Fatalf("updateStatus() unexpected error: %v", err)
+			}
+			got, err := f.tpods.AjV1().TrackPods(tpod.Namespace).Get(context.Background(), tpod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reasons := []string{}
+			for _, failure := range got.Status.FailureReasons {
+				if failure.Reason != "CrashLoopBackOff" || failure.Container != "main" {
+					t.Errorf("updateStatus() reports failure %+v, want main crash looping", failure)
+				}
+				reasons = append(reasons, failure.Pod)
+			}
+			if tt.wantReasons == nil {
+				tt.wantReasons = []string{}
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("updateStatus() reports the failures of %v, want %v", reasons, tt.wantReasons)
+			}
+
+			cond := meta.FindStatusCondition(got.Status.Conditions, v1.TrackPodDegraded)
+			switch {
+			case tt.wantDegraded == "" && (cond == nil || cond.Status != metav1.ConditionFalse):
+				t.Errorf("updateStatus() sets Degraded to %+v, want False", cond)
+			case tt.wantDegraded != "" && (cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != PodsFailing || !strings.Contains(cond.Message, tt.wantDegraded)):
+				t.Errorf("updateStatus() sets Degraded to %+v, want True with %q", cond, tt.wantDegraded)
+			}
+			if events := f.events(PodsFailing); len(events) != 0 && tt.wantDegraded == "" || len(events) != 1 && tt.wantDegraded != "" {
+				t.Errorf("updateStatus() records %v, want a single event once degraded", events)
+			}
+		})
+	}
+}
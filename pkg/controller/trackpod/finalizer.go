@@ -0,0 +1,107 @@
+package trackpod
+
+import (
+	"context"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	"github.com/apoorvajagtap/trackPodCRD/pkg/controller/archive"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// finalizerName holds the deletion of TrackPod, until its pods are drained.
+const finalizerName = "aj.com/trackpod-cleanup"
+
+func hasFinalizer(tpod *v1.TrackPod) bool {
+	for _, f := range tpod.Finalizers {
+		if f == finalizerName {
+			return true
+		}
+	}
+	return false
+}
+
+// adds the finalizer to TrackPod, the update event gets it reconciled again.
+func (c *Controller) addFinalizer(tpod *v1.TrackPod) error {
+	t := tpod.DeepCopy()
+	t.Finalizers = append(t.Finalizers, finalizerName)
+	_, err := c.tpodClient.AjV1().TrackPods(t.Namespace).Update(context.Background(), t, metav1.UpdateOptions{})
+	return err
+}
+
+// finalize cleans up after a deleted TrackPod: archives it (if asked to),
+// drains its pods with the configured grace period & removes the finalizer
+// once all of them are gone. Returns whether pods are still draining.
+func (c *Controller) finalize(tpod *v1.TrackPod) (bool, error) {
+	if !hasFinalizer(tpod) {
+		return false, nil
+	}
+
+	pList, err := c.listPods(tpod)
+	if err != nil {
+		return false, err
+	}
+
+	// logs are archived before the pods are drained, they are gone along
+	// with the pods afterwards.
+	if archive.Enabled(tpod) {
+		created, err := archive.Archive(context.Background(), c.kubeClient, "TrackPod", tpod, tpod.Status, pList)
+		if err != nil {
+			return false, err
+		}
+		if created {
+			c.recorder.Eventf(tpod, corev1.EventTypeNormal, Archived, "Archived status & logs into ConfigMap %s", archive.Name("TrackPod", tpod))
+		}
+	}
+
+	deleted := 0
+	for _, pod := range pList {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		err := c.kubeClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{
+			GracePeriodSeconds: tpod.Spec.DeletionGracePeriodSeconds,
+		})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			c.recorder.Eventf(tpod, corev1.EventTypeWarning, FailedDelete, "Error deleting pod %s: %v", pod.Name, err)
+			return false, err
+		}
+		c.recorder.Eventf(tpod, corev1.EventTypeNormal, SuccessfulDelete, "Deleted pod: %s", pod.Name)
+		deleted++
+	}
+	if deleted > 0 {
+		c.recorder.Eventf(tpod, corev1.EventTypeNormal, Draining, "Waiting for %d pods to terminate", len(pList))
+	}
+	if len(pList) > 0 {
+		// pod delete events get TrackPod reconciled again.
+		return true, nil
+	}
+
+	t := tpod.DeepCopy()
+	t.Finalizers = removeString(t.Finalizers, finalizerName)
+	if _, err := c.tpodClient.AjV1().TrackPods(t.Namespace).Update(context.Background(), t, metav1.UpdateOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	klog.Infof("TrackPod %s is finalized", tpod.Name)
+	c.recorder.Event(tpod, corev1.EventTypeNormal, Finalized, "All pods are drained, TrackPod can be deleted")
+
+	return false, nil
+}
+
+func removeString(list []string, s string) []string {
+	var out []string
+	for _, item := range list {
+		if item != s {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,123 @@
+package trackpod
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// syncDisruptionBudget creates (or updates) the PodDisruptionBudget of
+// TrackPod as per spec.disruptionBudget, & deletes it once the budget is
+// dropped from spec. PodDisruptionBudget of the same name, not owned by
+// TrackPod, is left alone.
+func (c *Controller) syncDisruptionBudget(tpod *v1.TrackPod) error {
+	pdb, err := c.pdbLister.PodDisruptionBudgets(tpod.Namespace).Get(tpod.Name)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if pdb != nil && !metav1.IsControlledBy(pdb, tpod) {
+		return fmt.Errorf(MessageResourceExists, pdb.Name)
+	}
+
+	if tpod.Spec.DisruptionBudget == nil {
+		if pdb == nil {
+			return nil
+		}
+		err := c.kubeClient.PolicyV1().PodDisruptionBudgets(tpod.Namespace).Delete(context.TODO(), pdb.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		klog.Infof("deleted the PodDisruptionBudget of TrackPod %s", tpod.Name)
+		return nil
+	}
+
+	desired, err := newDisruptionBudget(tpod)
+	if err != nil {
+		return err
+	}
+	if pdb == nil {
+		if _, err := c.kubeClient.PolicyV1().PodDisruptionBudgets(tpod.Namespace).Create(context.TODO(), desired, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		c.recorder.Eventf(tpod, corev1.EventTypeNormal, SuccessfulCreate, "Created PodDisruptionBudget: %s", desired.Name)
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(pdb.Spec, desired.Spec) {
+		return nil
+	}
+	p := pdb.DeepCopy()
+	p.Spec = desired.Spec
+	_, err = c.kubeClient.PolicyV1().PodDisruptionBudgets(tpod.Namespace).Update(context.TODO(), p, metav1.UpdateOptions{})
+	return err
+}
+
+// PodDisruptionBudget of TrackPod, selecting the same pods as TrackPod does
+func newDisruptionBudget(tpod *v1.TrackPod) (*policyv1.PodDisruptionBudget, error) {
+	budget := tpod.Spec.DisruptionBudget
+	if (budget.MinAvailable == nil) == (budget.MaxUnavailable == nil) {
+		return nil, fmt.Errorf("disruptionBudget of TrackPod %s has to set exactly one of minAvailable or maxUnavailable", tpod.Name)
+	}
+
+	selector := tpod.Spec.Selector
+	if selector == nil {
+		selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"controller": tpod.Name,
+			},
+		}
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tpod.Name,
+			Namespace: tpod.Namespace,
+			Labels: map[string]string{
+				"controller": tpod.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(tpod, v1.SchemeGroupVersion.WithKind("TrackPod")),
+			},
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   budget.MinAvailable,
+			MaxUnavailable: budget.MaxUnavailable,
+			Selector:       selector.DeepCopy(),
+		},
+	}, nil
+}
+
+// sets the DisruptionBudgetSatisfied condition of TrackPod from the status
+// of its PodDisruptionBudget, the condition is dropped without a budget.
+func (c *Controller) setDisruptionBudgetCondition(tpod *v1.TrackPod) {
+	if tpod.Spec.DisruptionBudget == nil {
+		meta.RemoveStatusCondition(&tpod.Status.Conditions, v1.TrackPodDisruptionBudgetSatisfied)
+		return
+	}
+
+	pdb, err := c.pdbLister.PodDisruptionBudgets(tpod.Namespace).Get(tpod.Name)
+	if err != nil || !metav1.IsControlledBy(pdb, tpod) {
+		setCondition(tpod, v1.TrackPodDisruptionBudgetSatisfied, metav1.ConditionUnknown, "DisruptionBudgetNotFound",
+			"PodDisruptionBudget is yet to be created")
+		return
+	}
+	// status is yet to be computed by the disruption controller.
+	if pdb.Status.ObservedGeneration < pdb.Generation {
+		return
+	}
+
+	msg := fmt.Sprintf("%d/%d pods are healthy, %d disruptions allowed", pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy, pdb.Status.DisruptionsAllowed)
+	if pdb.Status.CurrentHealthy < pdb.Status.DesiredHealthy {
+		setCondition(tpod, v1.TrackPodDisruptionBudgetSatisfied, metav1.ConditionFalse, "InsufficientPods", msg)
+		return
+	}
+	setCondition(tpod, v1.TrackPodDisruptionBudgetSatisfied, metav1.ConditionTrue, "SufficientPods", msg)
+}
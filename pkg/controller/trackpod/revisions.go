@@ -0,0 +1,204 @@
+package trackpod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+// default number of old revisions kept for a TrackPod
+const defaultRevisionHistoryLimit = 10
+
+// snapshot of the pod template & the message, stored in a ControllerRevision.
+type revisionData struct {
+	Template corev1.PodTemplateSpec `json:"template"`
+	Message  string                 `json:"message,omitempty"`
+}
+
+// name of the ControllerRevision of the pod template with hash
+func revisionName(tpod *v1.TrackPod, hash string) string {
+	return fmt.Sprintf("%s-%s", tpod.Name, hash)
+}
+
+// lists the ControllerRevisions of TrackPod, oldest revision first.
+func (c *Controller) listRevisions(tpod *v1.TrackPod) ([]*appsv1.ControllerRevision, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		"controller": tpod.Name,
+	})
+	revs, err := c.revLister.ControllerRevisions(tpod.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []*appsv1.ControllerRevision
+	for _, rev := range revs {
+		if metav1.IsControlledBy(rev, tpod) {
+			owned = append(owned, rev)
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool {
+		return owned[i].Revision < owned[j].Revision
+	})
+	return owned, nil
+}
+
+// syncRevisions makes sure the current pod template has a ControllerRevision
+// with the highest revision number, & drops the old revisions over the
+// revisionHistoryLimit that no pod is running anymore.
+func (c *Controller) syncRevisions(tpod *v1.TrackPod, pList []*corev1.Pod) error {
+	revs, err := c.listRevisions(tpod)
+	if err != nil {
+		return err
+	}
+
+	hash := templateHash(tpod)
+	var latest int64
+	var current *appsv1.ControllerRevision
+	for _, rev := range revs {
+		if rev.Revision > latest {
+			latest = rev.Revision
+		}
+		if rev.Name == revisionName(tpod, hash) {
+			current = rev
+		}
+	}
+
+	switch {
+	case current == nil:
+		rev, err := newRevision(tpod, hash, latest+1)
+		if err != nil {
+			return err
+		}
+		if _, err := c.kubeClient.AppsV1().ControllerRevisions(tpod.Namespace).Create(context.TODO(), rev, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+		klog.Infof("created revision %d (%s) of TrackPod %s", latest+1, rev.Name, tpod.Name)
+	case current.Revision < latest:
+		// template is back to an older revision (e.g. rolled back), it
+		// becomes the latest one.
+		rev := current.DeepCopy()
+		rev.Revision = latest + 1
+		if _, err := c.kubeClient.AppsV1().ControllerRevisions(tpod.Namespace).Update(context.TODO(), rev, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return c.truncateHistory(tpod, revs, hash, pList)
+}
+
+func newRevision(tpod *v1.TrackPod, hash string, revision int64) (*appsv1.ControllerRevision, error) {
+	data, err := json.Marshal(revisionData{Template: tpod.Spec.Template, Message: tpod.Spec.Message})
+	if err != nil {
+		return nil, err
+	}
+	return &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionName(tpod, hash),
+			Namespace: tpod.Namespace,
+			Labels: map[string]string{
+				"controller":         tpod.Name,
+				podTemplateHashLabel: hash,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(tpod, v1.SchemeGroupVersion.WithKind("TrackPod")),
+			},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: revision,
+	}, nil
+}
+
+// deletes the oldest revisions over the revisionHistoryLimit, the revision
+// of the current template & the ones still run by any pod are always kept.
+func (c *Controller) truncateHistory(tpod *v1.TrackPod, revs []*appsv1.ControllerRevision, hash string, pList []*corev1.Pod) error {
+	limit := defaultRevisionHistoryLimit
+	if tpod.Spec.RevisionHistoryLimit != nil {
+		limit = int(*tpod.Spec.RevisionHistoryLimit)
+	}
+
+	live := map[string]bool{revisionName(tpod, hash): true}
+	for _, pod := range pList {
+		live[revisionName(tpod, pod.Labels[podTemplateHashLabel])] = true
+	}
+	var history []*appsv1.ControllerRevision
+	for _, rev := range revs {
+		if !live[rev.Name] {
+			history = append(history, rev)
+		}
+	}
+
+	for i := 0; i < len(history)-limit; i++ {
+		err := c.kubeClient.AppsV1().ControllerRevisions(tpod.Namespace).Delete(context.TODO(), history[i].Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		klog.V(4).Infof("deleted revision %d (%s) of TrackPod %s", history[i].Revision, history[i].Name, tpod.Name)
+	}
+	return nil
+}
+
+// rollback restores the pod template & message of spec.rollbackTo revision,
+// & clears spec.rollbackTo. The update gets the TrackPod reconciled again to
+// roll out the restored template.
+func (c *Controller) rollback(tpod *v1.TrackPod) error {
+	revs, err := c.listRevisions(tpod)
+	if err != nil {
+		return err
+	}
+
+	t := tpod.DeepCopy()
+	t.Spec.RollbackTo = nil
+
+	target := findRevision(revs, tpod.Spec.RollbackTo.Revision, revisionName(tpod, templateHash(tpod)))
+	if target == nil {
+		c.recorder.Eventf(tpod, corev1.EventTypeWarning, RollbackRevisionNotFound, "Unable to find revision %d to roll back to", tpod.Spec.RollbackTo.Revision)
+	} else {
+		var data revisionData
+		if err := json.Unmarshal(target.Data.Raw, &data); err != nil {
+			return fmt.Errorf("decoding revision %s: %w", target.Name, err)
+		}
+		t.Spec.Template = data.Template
+		t.Spec.Message = data.Message
+	}
+
+	if _, err := c.tpodClient.AjV1().TrackPods(t.Namespace).Update(context.Background(), t, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	if target != nil {
+		klog.Infof("TrackPod %s is rolled back to revision %d", tpod.Name, target.Revision)
+		c.recorder.Eventf(tpod, corev1.EventTypeNormal, RolledBack, "Rolled back from %s to revision %d (%s)", tpod.Status.UpdateRevision, target.Revision, target.Name)
+	}
+	return nil
+}
+
+// revision with the given number, 0 being the one just before current.
+func findRevision(revs []*appsv1.ControllerRevision, revision int64, current string) *appsv1.ControllerRevision {
+	if revision != 0 {
+		for _, rev := range revs {
+			if rev.Revision == revision {
+				return rev
+			}
+		}
+		return nil
+	}
+
+	// revisions are sorted oldest first.
+	var previous *appsv1.ControllerRevision
+	for _, rev := range revs {
+		if rev.Name == current {
+			continue
+		}
+		previous = rev
+	}
+	return previous
+}
@@ -0,0 +1,126 @@
+package trackpod
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// podTemplateHashLabel tells the pods of the current pod template apart from
+// the old ones, during a rollout.
+const podTemplateHashLabel = "pod-template-hash"
+
+// default maxSurge & maxUnavailable of the RollingUpdate strategy
+var defaultRollingValue = intstr.FromString("25%")
+
+// hash of the pod template & the message injected into it, any change to
+// either of them rolls out new pods.
+func templateHash(tpod *v1.TrackPod) string {
+	data, _ := json.Marshal(struct {
+		Template corev1.PodTemplateSpec `json:"template"`
+		Message  string                 `json:"message"`
+	}{tpod.Spec.Template, tpod.Spec.Message})
+
+	h := fnv.New32a()
+	h.Write(data)
+	return fmt.Sprintf("%x", h.Sum32())
+}
+
+// splits the pods into the ones of current pod template & the old ones.
+func splitPods(pList []*corev1.Pod, hash string) (newPods, oldPods []*corev1.Pod) {
+	for _, pod := range pList {
+		if pod.Labels[podTemplateHashLabel] == hash {
+			newPods = append(newPods, pod)
+		} else {
+			oldPods = append(oldPods, pod)
+		}
+	}
+	return newPods, oldPods
+}
+
+func isRecreate(tpod *v1.TrackPod) bool {
+	return tpod.Spec.Strategy.Type == v1.RecreateTrackPodStrategyType
+}
+
+// resolves maxSurge & maxUnavailable of TrackPod against its Count. Both
+// can't be 0, maxUnavailable is bumped to 1 in that case for the rollout
+// to make progress.
+func rollingValues(tpod *v1.TrackPod) (int, int, error) {
+	surge, unavailable := &defaultRollingValue, &defaultRollingValue
+	if ru := tpod.Spec.Strategy.RollingUpdate; ru != nil {
+		if ru.MaxSurge != nil {
+			surge = ru.MaxSurge
+		}
+		if ru.MaxUnavailable != nil {
+			unavailable = ru.MaxUnavailable
+		}
+	}
+
+	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(surge, tpod.Spec.Count, true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxSurge: %w", err)
+	}
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(unavailable, tpod.Spec.Count, false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid maxUnavailable: %w", err)
+	}
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxUnavailable = 1
+	}
+	return maxSurge, maxUnavailable, nil
+}
+
+// rollingUpdate works out a single step of the rollout, with the current
+// pods. Returns the number of new pods to be created & the old pods to be
+// deleted, the next steps are taken by the following reconciles.
+func rollingUpdate(tpod *v1.TrackPod, newPods, oldPods []*corev1.Pod) (int, []*corev1.Pod, error) {
+	maxSurge, maxUnavailable, err := rollingValues(tpod)
+	if err != nil {
+		return 0, nil, err
+	}
+	desired := tpod.Spec.Count
+
+	// scale up the new pods, within the surge. Once enough new pods are
+	// there, old ones are still only deleted as they become available.
+	create := desired + maxSurge - (len(newPods) + len(oldPods))
+	if create > desired-len(newPods) {
+		create = desired - len(newPods)
+	}
+	if create < 0 {
+		create = 0
+	}
+
+	// scale down the old pods, keeping at least desired - maxUnavailable
+	// of the pods available. Old pods that aren't available don't count
+	// towards it & are deleted first, the rest as per their rank.
+	now := time.Now()
+	available := 0
+	for _, pods := range [][]*corev1.Pod{newPods, oldPods} {
+		for _, pod := range pods {
+			if isPodAvailable(pod, tpod.Spec.MinReadySeconds, now) {
+				available++
+			}
+		}
+	}
+	removable := available - (desired - maxUnavailable)
+
+	var victims []*corev1.Pod
+	for _, pod := range rankPods(tpod, oldPods) {
+		if !isPodAvailable(pod, tpod.Spec.MinReadySeconds, now) {
+			victims = append(victims, pod)
+			continue
+		}
+		if removable <= 0 {
+			continue
+		}
+		victims = append(victims, pod)
+		removable--
+	}
+
+	return create, victims, nil
+}
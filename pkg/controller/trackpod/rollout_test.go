@@ -0,0 +1,216 @@
+package trackpod
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// base time of the test pods, they are created & turn ready an hour before
+// it, in the order of their minutes.
+var testNow = time.Now()
+
+// running pod scheduled on a node, created (& ready, if so) at minute of the
+// hour before testNow.
+func testPod(name string, ready bool, minute int) *corev1.Pod {
+	at := metav1.NewTime(testNow.Add(-time.Hour + time.Duration(minute)*time.Minute))
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: at},
+		Spec:       corev1.PodSpec{NodeName: "node"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: status, LastTransitionTime: at},
+			},
+		},
+	}
+}
+
+func rollingTrackPod(count int, maxSurge, maxUnavailable *intstr.IntOrString) *v1.TrackPod {
+	tpod := &v1.TrackPod{Spec: v1.TrackPodSpec{Count: count}}
+	tpod.Spec.Strategy.Type = v1.RollingUpdateTrackPodStrategyType
+	if maxSurge != nil || maxUnavailable != nil {
+		tpod.Spec.Strategy.RollingUpdate = &v1.RollingUpdateTrackPod{MaxSurge: maxSurge, MaxUnavailable: maxUnavailable}
+	}
+	return tpod
+}
+
+func intOrString(s string) *intstr.IntOrString {
+	v := intstr.Parse(s)
+	return &v
+}
+
+func podNames(pods []*corev1.Pod) []string {
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestRollingValues(t *testing.T) {
+	tests := []struct {
+		name            string
+		tpod            *v1.TrackPod
+		wantSurge       int
+		wantUnavailable int
+		wantErr         string
+	}{
+		// 25% of the defaults rounds maxSurge up & maxUnavailable down.
+		{name: "defaults of 1 pod", tpod: rollingTrackPod(1, nil, nil), wantSurge: 1, wantUnavailable: 0},
+		{name: "defaults of 2 pods", tpod: rollingTrackPod(2, nil, nil), wantSurge: 1, wantUnavailable: 0},
+		{name: "defaults of 4 pods", tpod: rollingTrackPod(4, nil, nil), wantSurge: 1, wantUnavailable: 1},
+		{name: "defaults of 10 pods", tpod: rollingTrackPod(10, nil, nil), wantSurge: 3, wantUnavailable: 2},
+		{name: "no pods", tpod: rollingTrackPod(0, nil, nil), wantSurge: 0, wantUnavailable: 1},
+		{name: "integers", tpod: rollingTrackPod(3, intOrString("2"), intOrString("1")), wantSurge: 2, wantUnavailable: 1},
+		{name: "only maxSurge set", tpod: rollingTrackPod(10, intOrString("50%"), nil), wantSurge: 5, wantUnavailable: 2},
+		{name: "only maxUnavailable set", tpod: rollingTrackPod(10, nil, intOrString("100%")), wantSurge: 3, wantUnavailable: 10},
+		{name: "both 0", tpod: rollingTrackPod(3, intOrString("0"), intOrString("0%")), wantSurge: 0, wantUnavailable: 1},
+		{name: "percentages of 3 pods", tpod: rollingTrackPod(3, intOrString("10%"), intOrString("90%")), wantSurge: 1, wantUnavailable: 2},
+		{name: "invalid maxSurge", tpod: rollingTrackPod(3, intOrString("many"), nil), wantErr: "invalid maxSurge"},
+		{name: "invalid maxUnavailable", tpod: rollingTrackPod(3, nil, intOrString("x%")), wantErr: "invalid maxUnavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			surge, unavailable, err := rollingValues(tt.tpod)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("rollingValues() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("rollingValues() unexpected error: %v", err)
+			}
+			if surge != tt.wantSurge || unavailable != tt.wantUnavailable {
+				t.Errorf("rollingValues() = %d, %d, want %d, %d", surge, unavailable, tt.wantSurge, tt.wantUnavailable)
+			}
+		})
+	}
+}
+
+func TestRollingUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		tpod        *v1.TrackPod
+		newPods     []*corev1.Pod
+		oldPods     []*corev1.Pod
+		wantCreate  int
+		wantVictims []string
+	}{
+		{
+			name:        "single pod starts by surging",
+			tpod:        rollingTrackPod(1, nil, nil),
+			oldPods:     []*corev1.Pod{testPod("old", true, 0)},
+			wantCreate:  1,
+			wantVictims: []string{},
+		},
+		{
+			name:        "single pod is kept until the new one is available",
+			tpod:        rollingTrackPod(1, nil, nil),
+			newPods:     []*corev1.Pod{testPod("new", false, 10)},
+			oldPods:     []*corev1.Pod{testPod("old", true, 0)},
+			wantCreate:  0,
+			wantVictims: []string{},
+		},
+		{
+			name:        "single pod is replaced once the new one is available",
+			tpod:        rollingTrackPod(1, nil, nil),
+			newPods:     []*corev1.Pod{testPod("new", true, 10)},
+			oldPods:     []*corev1.Pod{testPod("old", true, 0)},
+			wantCreate:  0,
+			wantVictims: []string{"old"},
+		},
+		{
+			name: "new pod ready within minReadySeconds isn't available",
+			tpod: func() *v1.TrackPod {
+				tpod := rollingTrackPod(1, nil, nil)
+				tpod.Spec.MinReadySeconds = 30 * 60
+				return tpod
+			}(),
+			newPods:     []*corev1.Pod{testPod("new", true, 59)},
+			oldPods:     []*corev1.Pod{testPod("old", true, 0)},
+			wantCreate:  0,
+			wantVictims: []string{},
+		},
+		{
+			name:        "enough new pods that aren't available",
+			tpod:        rollingTrackPod(2, nil, nil),
+			newPods:     []*corev1.Pod{testPod("new-1", false, 10), testPod("new-2", false, 11)},
+			oldPods:     []*corev1.Pod{testPod("old-1", true, 0), testPod("old-2", true, 1)},
+			wantCreate:  0,
+			wantVictims: []string{},
+		},
+		{
+			name:        "enough new pods, some of them available",
+			tpod:        rollingTrackPod(2, nil, nil),
+			newPods:     []*corev1.Pod{testPod("new-1", true, 10), testPod("new-2", false, 11)},
+			oldPods:     []*corev1.Pod{testPod("old-1", true, 0), testPod("old-2", true, 1)},
+			wantCreate:  0,
+			wantVictims: []string{"old-2"},
+		},
+		{
+			name:        "enough new pods, all of them available",
+			tpod:        rollingTrackPod(2, nil, nil),
+			newPods:     []*corev1.Pod{testPod("new-1", true, 10), testPod("new-2", true, 11)},
+			oldPods:     []*corev1.Pod{testPod("old-1", true, 0), testPod("old-2", true, 1)},
+			wantCreate:  0,
+			wantVictims: []string{"old-2", "old-1"},
+		},
+		{
+			name:        "surge & unavailable of 4 pods",
+			tpod:        rollingTrackPod(4, nil, nil),
+			oldPods:     []*corev1.Pod{testPod("old-1", true, 0), testPod("old-2", true, 1), testPod("old-3", true, 2), testPod("old-4", true, 3)},
+			wantCreate:  1,
+			wantVictims: []string{"old-4"},
+		},
+		{
+			name:        "unavailable old pods are always deleted",
+			tpod:        rollingTrackPod(2, nil, nil),
+			oldPods:     []*corev1.Pod{testPod("old-ready", true, 0), testPod("old-unready", false, 1)},
+			wantCreate:  1,
+			wantVictims: []string{"old-unready"},
+		},
+		{
+			name:        "no surge deletes first",
+			tpod:        rollingTrackPod(2, intOrString("0"), intOrString("0")),
+			oldPods:     []*corev1.Pod{testPod("old-1", true, 0), testPod("old-2", true, 1)},
+			wantCreate:  0,
+			wantVictims: []string{"old-2"},
+		},
+		{
+			name:        "surge isn't exceeded",
+			tpod:        rollingTrackPod(3, intOrString("1"), intOrString("0")),
+			newPods:     []*corev1.Pod{testPod("new-1", false, 10)},
+			oldPods:     []*corev1.Pod{testPod("old-1", true, 0), testPod("old-2", true, 1), testPod("old-3", true, 2)},
+			wantCreate:  0,
+			wantVictims: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create, victims, err := rollingUpdate(tt.tpod, tt.newPods, tt.oldPods)
+			if err != nil {
+				t.Fatalf("rollingUpdate() unexpected error: %v", err)
+			}
+			if create != tt.wantCreate {
+				t.Errorf("rollingUpdate() creates %d pods, want %d", create, tt.wantCreate)
+			}
+			if got := podNames(victims); !reflect.DeepEqual(got, tt.wantVictims) {
+				t.Errorf("rollingUpdate() deletes %v, want %v", got, tt.wantVictims)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package trackpod
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Updates the status section of TrackPod, syncErr is the error (if any)
+// the current reconcile has failed with.
+func (c *Controller) updateStatus(tpod *v1.TrackPod, syncErr error) error {
+	pList, err := c.listPods(tpod)
+	if err != nil {
+		return err
+	}
+
+	active := activePods(pList)
+	newPods, oldPods := splitPods(active, templateHash(tpod))
+	now := time.Now()
+	runningPods, readyPods, availablePods := 0, 0, 0
+	for _, pod := range active {
+		if pod.Status.Phase == corev1.PodRunning {
+			runningPods++
+		}
+		if isPodReady(pod) {
+			readyPods++
+		}
+		if isPodAvailable(pod, tpod.Spec.MinReadySeconds, now) {
+			availablePods++
+		}
+	}
+
+	t := tpod.DeepCopy()
+	t.Status.Count = runningPods
+	t.Status.ObservedGeneration = tpod.Generation
+	t.Status.ReadyReplicas = readyPods
+	t.Status.AvailableReplicas = availablePods
+	t.Status.UpdatedReplicas = len(newPods)
+	t.Status.Phases, t.Status.Restarts = podAccounting(pList)
+	t.Status.FailureReasons = failureReasons(pList)
+	// selector of the scale subresource, an invalid one is reported by
+	// the sync itself.
+	if selector, err := podSelector(tpod); err == nil {
+		t.Status.Selector = selector.String()
+	}
+	// message & revision are only reported once all the pods carry it.
+	t.Status.UpdateRevision = revisionName(tpod, templateHash(tpod))
+	if len(oldPods) == 0 {
+		t.Status.Message = tpod.Spec.Message
+		t.Status.CurrentRevision = t.Status.UpdateRevision
+	} else if t.Status.CurrentRevision == "" {
+		t.Status.CurrentRevision = revisionName(tpod, oldPods[0].Labels[podTemplateHashLabel])
+	}
+	// evictions blocked by a disruption budget are reported on their own,
+	// TrackPod isn't degraded by them.
+	t.Status.BlockedEvictions = nil
+	if blocked := evictionBlocked(syncErr); blocked != nil {
+		t.Status.BlockedEvictions = blocked.pods
+		syncErr = nil
+	}
+	setConditions(t, len(active), len(oldPods), syncErr, degradedPods(pList), now)
+	c.setDisruptionBudgetCondition(t)
+
+	if equality.Semantic.DeepEqual(tpod.Status, t.Status) {
+		return nil
+	}
+	_, err = c.tpodClient.AjV1().TrackPods(tpod.Namespace).UpdateStatus(context.Background(), t, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !meta.IsStatusConditionTrue(tpod.Status.Conditions, v1.TrackPodReady) && meta.IsStatusConditionTrue(t.Status.Conditions, v1.TrackPodReady) {
+		c.recorder.Event(tpod, corev1.EventTypeNormal, SuccessSynced, MessageResourceSynced)
+	}
+	if cond := meta.FindStatusCondition(t.Status.Conditions, v1.TrackPodProgressing); cond.Reason == v1.ProgressDeadlineExceeded {
+		if prev := meta.FindStatusCondition(tpod.Status.Conditions, v1.TrackPodProgressing); prev == nil || prev.Reason != v1.ProgressDeadlineExceeded {
+			c.recorder.Event(tpod, corev1.EventTypeWarning, v1.ProgressDeadlineExceeded, cond.Message)
+		}
+	}
+	if cond := meta.FindStatusCondition(t.Status.Conditions, v1.TrackPodDegraded); cond.Reason == PodsFailing {
+		if prev := meta.FindStatusCondition(tpod.Status.Conditions, v1.TrackPodDegraded); prev == nil || prev.Reason != PodsFailing {
+			c.recorder.Event(tpod, corev1.EventTypeWarning, PodsFailing, cond.Message)
+		}
+	}
+	wasPaused := meta.IsStatusConditionTrue(tpod.Status.Conditions, v1.TrackPodPaused)
+	switch {
+	case !wasPaused && tpod.Spec.Paused:
+		c.recorder.Event(tpod, corev1.EventTypeNormal, Paused, "TrackPod is paused")
+	case wasPaused && !tpod.Spec.Paused:
+		c.recorder.Event(tpod, corev1.EventTypeNormal, Resumed, "TrackPod is resumed")
+	}
+	return nil
+}
+
+// number of pods in each phase & the total container restarts, pods that
+// are being deleted aren't accounted for.
+func podAccounting(pList []*corev1.Pod) (v1.PodPhaseCounts, int32) {
+	var phases v1.PodPhaseCounts
+	var restarts int32
+	for _, pod := range pList {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		switch pod.Status.Phase {
+		case corev1.PodPending:
+			phases.Pending++
+		case corev1.PodRunning:
+			phases.Running++
+		case corev1.PodSucceeded:
+			phases.Succeeded++
+		case corev1.PodFailed:
+			phases.Failed++
+		default:
+			phases.Unknown++
+		}
+		for _, status := range pod.Status.InitContainerStatuses {
+			restarts += status.RestartCount
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+	}
+	return phases, restarts
+}
+
+// reasons the containers are failing with, of the most recent pods first.
+// The list is bounded by maxFailureReasons.
+func failureReasons(pList []*corev1.Pod) []v1.PodFailure {
+	pods := make([]*corev1.Pod, len(pList))
+	copy(pods, pList)
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+
+	var failures []v1.PodFailure
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			reason, message := containerFailure(status)
+			if reason == "" {
+				continue
+			}
+			failures = append(failures, v1.PodFailure{
+				Pod:       pod.Name,
+				Container: status.Name,
+				Reason:    reason,
+				Message:   message,
+			})
+			if len(failures) == maxFailureReasons {
+				return failures
+			}
+		}
+	}
+	return failures
+}
+
+// reason the container is failing with, if it's failing at all. A waiting
+// container is failing unless it's still being created, a terminated one if
+// it hasn't completed successfully.
+func containerFailure(status corev1.ContainerStatus) (string, string) {
+	if w := status.State.Waiting; w != nil && w.Reason != "" && w.Reason != "ContainerCreating" && w.Reason != "PodInitializing" {
+		// a crash looping container is better explained by its last
+		// termination (e.g. OOMKilled).
+		if t := status.LastTerminationState.Terminated; w.Reason == "CrashLoopBackOff" && t != nil && t.Reason != "" && t.Reason != "Completed" {
+			return w.Reason, fmt.Sprintf("last terminated with %s (exit code %d)", t.Reason, t.ExitCode)
+		}
+		return w.Reason, w.Message
+	}
+	if t := status.State.Terminated; t != nil && t.ExitCode != 0 {
+		reason := t.Reason
+		if reason == "" {
+			reason = "Error"
+		}
+		return reason, t.Message
+	}
+	return "", ""
+}
+
+// sets the Ready, Progressing & Degraded conditions of TrackPod, as per its
+// (already computed) status and the number of pods currently present, of
+// which oldPods are yet to be replaced.
+func setConditions(tpod *v1.TrackPod, currentPods, oldPods int, syncErr error, degraded []string, now time.Time) {
+	var prev *metav1.Condition
+	if cond := meta.FindStatusCondition(tpod.Status.Conditions, v1.TrackPodProgressing); cond != nil {
+		prev = cond.DeepCopy()
+	}
+
+	desired := tpod.Spec.Count
+	ready := tpod.Status.ReadyReplicas
+	readyMsg := fmt.Sprintf("%d/%d pods are ready", ready, desired)
+
+	if ready == desired && currentPods == desired && oldPods == 0 {
+		setCondition(tpod, v1.TrackPodReady, metav1.ConditionTrue, "PodsReady", readyMsg)
+	} else {
+		setCondition(tpod, v1.TrackPodReady, metav1.ConditionFalse, "PodsNotReady", readyMsg)
+	}
+
+	// Progressing is worked out in full before it's set, a condition set
+	// back & forth gets a new transition time on every sync.
+	status, reason, message := metav1.ConditionTrue, "", ""
+	switch {
+	case oldPods > 0:
+		reason, message = "RollingOut", fmt.Sprintf("%d/%d pods are updated", tpod.Status.UpdatedReplicas, desired)
+	case currentPods < desired:
+		reason, message = "ScalingUp", fmt.Sprintf("%d/%d pods are created", currentPods, desired)
+	case currentPods > desired:
+		reason, message = "ScalingDown", fmt.Sprintf("%d pods are yet to be deleted", currentPods-desired)
+	case ready < desired:
+		reason, message = "PodsStarting", readyMsg
+	case tpod.Status.AvailableReplicas < desired:
+		reason, message = "PodsNotAvailable", fmt.Sprintf("%d/%d pods are available", tpod.Status.AvailableReplicas, desired)
+	default:
+		status, reason, message = metav1.ConditionFalse, "Complete", readyMsg
+	}
+
+	// TrackPod still progressing past the deadline has failed to progress,
+	// it's only reset by a change of spec (i.e. a new generation).
+	if status == metav1.ConditionTrue && prev != nil {
+		deadline := time.Duration(progressDeadlineSeconds(tpod)) * time.Second
+		switch {
+		case prev.Reason == v1.ProgressDeadlineExceeded && prev.ObservedGeneration == tpod.Generation:
+			status, reason, message = metav1.ConditionFalse, v1.ProgressDeadlineExceeded, prev.Message
+		case prev.Status == metav1.ConditionTrue && now.Sub(prev.LastTransitionTime.Time) > deadline:
+			status, reason, message = metav1.ConditionFalse, v1.ProgressDeadlineExceeded,
+				fmt.Sprintf("TrackPod didn't progress within %v, %s", deadline, readyMsg)
+		}
+	}
+	// no progress is made while paused.
+	if tpod.Spec.Paused && status == metav1.ConditionTrue {
+		status, reason, message = metav1.ConditionUnknown, "Paused", readyMsg
+	}
+	setCondition(tpod, v1.TrackPodProgressing, status, reason, message)
+
+	if tpod.Spec.Paused {
+		setCondition(tpod, v1.TrackPodPaused, metav1.ConditionTrue, "Paused", "TrackPod is paused, pods aren't being reconciled")
+	} else {
+		setCondition(tpod, v1.TrackPodPaused, metav1.ConditionFalse, "NotPaused", "")
+	}
+
+	switch {
+	case syncErr != nil:
+		setCondition(tpod, v1.TrackPodDegraded, metav1.ConditionTrue, "ReconcileError", syncErr.Error())
+	case len(degraded) > 0:
+		setCondition(tpod, v1.TrackPodDegraded, metav1.ConditionTrue, PodsFailing, degradedMessage(degraded))
+	default:
+		setCondition(tpod, v1.TrackPodDegraded, metav1.ConditionFalse, "AsExpected", "")
+	}
+}
+
+func setCondition(tpod *v1.TrackPod, condType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&tpod.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: tpod.Generation,
+	})
+}
+
+func progressDeadlineSeconds(tpod *v1.TrackPod) int32 {
+	if tpod.Spec.ProgressDeadlineSeconds != nil {
+		return *tpod.Spec.ProgressDeadlineSeconds
+	}
+	return defaultProgressDeadlineSeconds
+}
+
+// pod is available when it's been ready for at least minReadySeconds
+func isPodAvailable(pod *corev1.Pod, minReadySeconds int32, now time.Time) bool {
+	if !isPodReady(pod) {
+		return false
+	}
+	minReady := time.Duration(minReadySeconds) * time.Second
+	return minReadySeconds == 0 || !readyTime(pod).Add(minReady).After(now)
+}
+
+// pod is ready when its Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package trackpod
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrackPod of count pods with ready of them ready, available & updated.
+func conditionsTrackPod(count, ready int) *v1.TrackPod {
+	tpod := &v1.TrackPod{Spec: v1.TrackPodSpec{Count: count}}
+	tpod.Generation = 1
+	tpod.Status.ReadyReplicas = ready
+	tpod.Status.AvailableReplicas = ready
+	tpod.Status.UpdatedReplicas = count
+	return tpod
+}
+
+// TrackPod progressing since the time ago, with a deadline of a minute.
+func progressingTrackPod(count, ready int, since time.Duration) *v1.TrackPod {
+	tpod := conditionsTrackPod(count, ready)
+	deadline := int32(60)
+	tpod.Spec.ProgressDeadlineSeconds = &deadline
+	tpod.Status.Conditions = []metav1.Condition{{
+		Type:               v1.TrackPodProgressing,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ScalingUp",
+		ObservedGeneration: tpod.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+	}}
+	return tpod
+}
+
+func pausedTrackPod(tpod *v1.TrackPod) *v1.TrackPod {
+	tpod.Spec.Paused = true
+	return tpod
+}
+
+// moves the transition times of the conditions of tpod back by d, as if
+// they were set d earlier.
+func ageConditions(tpod *v1.TrackPod, d time.Duration) {
+	for i := range tpod.Status.Conditions {
+		cond := &tpod.Status.Conditions[i]
+		cond.LastTransitionTime = metav1.NewTime(cond.LastTransitionTime.Add(-d))
+	}
+}
+
+func TestSetConditions(t *testing.T) {
+	tests := []struct {
+		name            string
+		tpod            *v1.TrackPod
+		currentPods     int
+		oldPods         int
+		wantProgressing metav1.ConditionStatus
+		wantReason      string
+	}{
+		{
+			name:            "scaling up",
+			tpod:            conditionsTrackPod(3, 1),
+			currentPods:     1,
+			wantProgressing: metav1.ConditionTrue,
+			wantReason:      "ScalingUp",
+		},
+		{
+			name:            "rolling out",
+			tpod:            conditionsTrackPod(3, 3),
+			currentPods:     4,
+			oldPods:         1,
+			wantProgressing: metav1.ConditionTrue,
+			wantReason:      "RollingOut",
+		},
+		{
+			name:            "complete",
+			tpod:            conditionsTrackPod(3, 3),
+			currentPods:     3,
+			wantProgressing: metav1.ConditionFalse,
+			wantReason:      "Complete",
+		},
+		{
+			name:            "within the progress deadline",
+			tpod:            progressingTrackPod(3, 1, 30*time.Second),
+			currentPods:     1,
+			wantProgressing: metav1.ConditionTrue,
+			wantReason:      "ScalingUp",
+		},
+		{
+			name:            "progress deadline exceeded",
+			tpod:            progressingTrackPod(3, 1, time.Hour),
+			currentPods:     1,
+			wantProgressing: metav1.ConditionFalse,
+			wantReason:      v1.ProgressDeadlineExceeded,
+		},
+		{
+			name:            "paused below count",
+			tpod:            pausedTrackPod(conditionsTrackPod(3, 1)),
+			currentPods:     1,
+			wantProgressing: metav1.ConditionUnknown,
+			wantReason:      "Paused",
+		},
+		{
+			name:            "paused while rolling out",
+			tpod:            pausedTrackPod(conditionsTrackPod(3, 3)),
+			currentPods:     3,
+			oldPods:         2,
+			wantProgressing: metav1.ConditionUnknown,
+			wantReason:      "Paused",
+		},
+		{
+			name:            "paused once complete",
+			tpod:            pausedTrackPod(conditionsTrackPod(3, 3)),
+			currentPods:     3,
+			wantProgressing: metav1.ConditionFalse,
+			wantReason:      "Complete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setConditions(tt.tpod, tt.currentPods, tt.oldPods, nil, nil, time.Now())
+			cond := meta.FindStatusCondition(tt.tpod.Status.Conditions, v1.TrackPodProgressing)
+			if cond == nil || cond.Status != tt.wantProgressing || cond.Reason != tt.wantReason {
+				t.Fatalf("setConditions() sets Progressing to %+v, want %s/%s", cond, tt.wantProgressing, tt.wantReason)
+			}
+
+			// nothing has changed since, a status that isn't identical
+			// would be written on every sync.
+			ageConditions(tt.tpod, 5*time.Second)
+			before, _ := json.Marshal(tt.tpod.Status)
+			setConditions(tt.tpod, tt.currentPods, tt.oldPods, nil, nil, time.Now())
+			after, _ := json.Marshal(tt.tpod.Status)
+			if !bytes.Equal(before, after) {
+				t.Errorf("setConditions() run again changes the status\nfrom %s\nto   %s", before, after)
+			}
+		})
+	}
+}
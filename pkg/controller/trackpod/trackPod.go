@@ -3,42 +3,90 @@ package trackpod
 import (
 	"context"
 	"fmt"
-	"log"
+	"sync"
 	"time"
 
 	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
 	tClientSet "github.com/apoorvajagtap/trackPodCRD/pkg/client/clientset/versioned"
+	tScheme "github.com/apoorvajagtap/trackPodCRD/pkg/client/clientset/versioned/scheme"
 	tInformer "github.com/apoorvajagtap/trackPodCRD/pkg/client/informers/externalversions/trackpod/v1"
 	tLister "github.com/apoorvajagtap/trackPodCRD/pkg/client/listers/trackpod/v1"
-	"github.com/kanisterio/kanister/pkg/poll"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	appsInformer "k8s.io/client-go/informers/apps/v1"
+	coreInformer "k8s.io/client-go/informers/core/v1"
+	policyInformer "k8s.io/client-go/informers/policy/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	appsLister "k8s.io/client-go/listers/apps/v1"
+	coreLister "k8s.io/client-go/listers/core/v1"
+	policyLister "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
+const controllerAgentName = "trackpod-controller"
+
 const (
-	// SuccessSynced is used as part of the Event 'reason' when a Foo is synced
+	// SuccessSynced is used as part of the Event 'reason' when a TrackPod is synced
 	SuccessSynced = "Synced"
-	// ErrResourceExists is used as part of the Event 'reason' when a Foo fails
-	// to sync due to a Deployment of the same name already existing.
+	// ErrResourceExists is used as part of the Event 'reason' when a TrackPod fails
+	// to sync due to a resource of the same name already existing.
 	ErrResourceExists = "ErrResourceExists"
 
 	// MessageResourceExists is the message used for Events when a resource
-	// fails to sync due to a Deployment already existing
+	// fails to sync due to a resource already existing
 	MessageResourceExists = "Resource %q already exists and is not managed by TrackPod"
-	// MessageResourceSynced is the message used for an Event fired when a Foo
-	// is synced successfully
+	// MessageResourceSynced is the message used for an Event fired when a TrackPod
+	// is synced successfully, i.e. all of its pods are ready.
 	MessageResourceSynced = "TrackPod synced successfully"
+
+	// Event reasons for the actions taken on pods of TrackPod
+	SuccessfulCreate = "SuccessfulCreate"
+	FailedCreate     = "FailedCreate"
+	SuccessfulDelete = "SuccessfulDelete"
+	FailedDelete     = "FailedDelete"
+	ScaleMismatch    = "ScaleMismatch"
+	MessageChanged   = "MessageChanged"
+	FailedSync       = "FailedSync"
+	Draining         = "Draining"
+	Archived         = "Archived"
+	Finalized        = "Finalized"
+	AdoptedPod       = "AdoptedPod"
+	ReleasedPod      = "ReleasedPod"
+	RolledBack       = "RolledBack"
+	Paused           = "Paused"
+	Resumed          = "Resumed"
+	FailedPod        = "FailedPod"
+	SuccessfulEvict  = "SuccessfulEvict"
+	FailedEvict      = "FailedEvict"
+	EvictionBlocked  = "EvictionBlocked"
+	PodsFailing      = "PodsFailing"
+
+	RollbackRevisionNotFound = "RollbackRevisionNotFound"
+
+	// default progressDeadlineSeconds of TrackPod
+	defaultProgressDeadlineSeconds = 600
+	// TrackPods that are yet to meet the desired state are checked again
+	// after requeueInterval, in case no pod event triggers it before.
+	requeueInterval = 30 * time.Second
+	// size of the first batch of pods created at once, the batches double
+	// in size as long as the pods are created successfully.
+	slowStartInitialBatchSize = 1
+	// failure reasons reported in the status of TrackPod are bounded by
+	// maxFailureReasons.
+	maxFailureReasons = 10
 )
 
 // Controller implementation for TrackPod resources
-// TODO: Record events
 type Controller struct {
 	// K8s clientset
 	kubeClient kubernetes.Interface
@@ -49,6 +97,16 @@ type Controller struct {
 	tpodSync cache.InformerSynced
 	// - interface provided by informer
 	tpodlister tLister.TrackPodLister
+	// - pod cache has synced & the lister to query pods from the cache,
+	// instead of hitting the K8s API on every reconcile.
+	podSync   cache.InformerSynced
+	podLister coreLister.PodLister
+	// - ControllerRevisions of the pod templates of TrackPods
+	revSync   cache.InformerSynced
+	revLister appsLister.ControllerRevisionLister
+	// - PodDisruptionBudgets of TrackPods
+	pdbSync   cache.InformerSynced
+	pdbLister policyLister.PodDisruptionBudgetLister
 	// - queue
 	// stores the work that has to be processed, instead of performing
 	// as soon as it's changed.
@@ -56,16 +114,46 @@ type Controller struct {
 	// time, and makes it easy to ensure we are never processing the same item
 	// simultaneously in two different workers.
 	wq workqueue.RateLimitingInterface
+	// number of times a failing key is retried, before it's dropped
+	// out of the queue.
+	maxRetries int
+	// pods created/deleted by the TrackPods, that are yet to be observed
+	// in the pod cache.
+	expectations *expectations
+	// backoff of replacing the failed pods, per TrackPod key.
+	failedPodsBackoff *failureBackoff
+	// recorder is an event recorder for recording Event resources to the
+	// Kubernetes API.
+	recorder record.EventRecorder
 }
 
 // returns a new TrackPod controller
-func NewController(kubeClient kubernetes.Interface, tpodClient tClientSet.Interface, tpodInformer tInformer.TrackPodInformer) *Controller {
+func NewController(kubeClient kubernetes.Interface, tpodClient tClientSet.Interface, tpodInformer tInformer.TrackPodInformer, podInformer coreInformer.PodInformer, revInformer appsInformer.ControllerRevisionInformer, pdbInformer policyInformer.PodDisruptionBudgetInformer, maxRetries int) *Controller {
+	// Add trackpod types to the default Kubernetes Scheme so Events can be
+	// logged for trackpod types.
+	utilruntime.Must(tScheme.AddToScheme(scheme.Scheme))
+	klog.V(4).Info("Creating event broadcaster")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerAgentName})
+
 	c := &Controller{
-		kubeClient: kubeClient,
-		tpodClient: tpodClient,
-		tpodSync:   tpodInformer.Informer().HasSynced,
-		tpodlister: tpodInformer.Lister(),
-		wq:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "TrackPod"),
+		kubeClient:        kubeClient,
+		tpodClient:        tpodClient,
+		tpodSync:          tpodInformer.Informer().HasSynced,
+		tpodlister:        tpodInformer.Lister(),
+		podSync:           podInformer.Informer().HasSynced,
+		podLister:         podInformer.Lister(),
+		revSync:           revInformer.Informer().HasSynced,
+		revLister:         revInformer.Lister(),
+		pdbSync:           pdbInformer.Informer().HasSynced,
+		pdbLister:         pdbInformer.Lister(),
+		wq:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "TrackPod"),
+		maxRetries:        maxRetries,
+		expectations:      newExpectations(),
+		failedPodsBackoff: newFailureBackoff(),
+		recorder:          recorder,
 	}
 
 	// event handler when the trackPod resources are added/deleted/updated.
@@ -84,6 +172,51 @@ func NewController(kubeClient kubernetes.Interface, tpodClient tClientSet.Interf
 		},
 	)
 
+	// event handler when the pods are added/deleted/updated, the owning
+	// TrackPod is queued to be reconciled.
+	podInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: c.addPod,
+			UpdateFunc: func(old, obj interface{}) {
+				oldPod := old.(*corev1.Pod)
+				newPod := obj.(*corev1.Pod)
+				if newPod.ResourceVersion == oldPod.ResourceVersion {
+					return
+				}
+				// pod deleted gracefully is observed as soon as it's
+				// marked for deletion.
+				if !newPod.DeletionTimestamp.IsZero() && oldPod.DeletionTimestamp.IsZero() {
+					c.observeDeletion(newPod)
+				}
+				// controller has changed, the old one has to know it's
+				// lost the pod.
+				oldRef, newRef := metav1.GetControllerOf(oldPod), metav1.GetControllerOf(newPod)
+				if oldRef != nil && (newRef == nil || oldRef.UID != newRef.UID) {
+					c.handlePod(oldPod)
+				}
+				c.handlePod(obj)
+			},
+			DeleteFunc: c.deletePod,
+		},
+	)
+
+	// status of the PodDisruptionBudgets is reflected in the conditions of
+	// the owning TrackPod.
+	pdbInformer.Informer().AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(old, obj interface{}) {
+				oldPdb := old.(*policyv1.PodDisruptionBudget)
+				newPdb := obj.(*policyv1.PodDisruptionBudget)
+				if newPdb.ResourceVersion == oldPdb.ResourceVersion {
+					return
+				}
+				c.enqueueController(newPdb)
+			},
+			// PDB deleted on its own is recreated by the owning TrackPod.
+			DeleteFunc: c.deletePdb,
+		},
+	)
+
 	return c
 }
 
@@ -91,22 +224,28 @@ func NewController(kubeClient kubernetes.Interface, tpodClient tClientSet.Interf
 // as syncing informer caches and starting workers. It will block until ch
 // is closed, at which point it will shutdown the workqueue and wait for
 // workers to finish processing their current work items.
-func (c *Controller) Run(ch chan struct{}) error {
+func (c *Controller) Run(workers int, ch <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
 	defer c.wq.ShutDown()
 
 	// Start the informer factories to begin populating the informer caches
 	klog.Info("Starting the TrackPod controller")
 
 	// Wait for the caches to be synced before starting workers
-	if ok := cache.WaitForCacheSync(ch, c.tpodSync); !ok {
-		log.Println("failed to wait for cache to sync")
+	if ok := cache.WaitForCacheSync(ch, c.tpodSync, c.podSync, c.revSync, c.pdbSync); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+	// Launch the workers to process the CR, each item of the queue is only
+	// processed by a single worker at a time.
+	klog.Infof("Starting %d workers", workers)
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, ch)
 	}
-	// Launch the goroutine for workers to process the CR
-	klog.Info("Starting workers")
-	go wait.Until(c.worker, time.Second, ch)
+	// expired backoff entries of the failed pods are dropped periodically.
+	go wait.Until(c.failedPodsBackoff.gc, time.Minute, ch)
 	klog.Info("Started workers")
 	<-ch
-	klog.Info("Shutting down the worker")
+	klog.Info("Shutting down the workers")
 
 	return nil
 }
@@ -127,154 +266,438 @@ func (c *Controller) processNextItem() bool {
 		klog.Info("Shutting down")
 		return false
 	}
+	// Done has to be called on the item, once it's processed. Otherwise the
+	// workqueue won't hand out the key again, even if it's added back.
+	defer c.wq.Done(item)
+
+	// queue only carries the namespace/name keys, the object itself is
+	// fetched from the lister for an up to date copy.
+	key, ok := item.(string)
+	if !ok {
+		// invalid item, no point retrying it.
+		c.wq.Forget(item)
+		utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", item))
+		return true
+	}
 
-	defer c.wq.Forget(item)
-	key, err := cache.MetaNamespaceKeyFunc(item)
-	if err != nil {
-		klog.Errorf("error while calling Namespace Key func on cache for item %s: %s", item, err.Error())
-		return false
+	progressing, err := c.reconcile(key)
+	c.handleErr(err, key)
+	if err == nil && progressing {
+		c.wq.AddAfter(key, requeueInterval)
 	}
 
+	return true
+}
+
+// handleErr forgets the key on success, so that its rate limit history is
+// reset. Failed keys are retried with the rate limiter, until maxRetries.
+func (c *Controller) handleErr(err error, key string) {
+	if err == nil {
+		c.wq.Forget(key)
+		return
+	}
+
+	if c.wq.NumRequeues(key) < c.maxRetries {
+		klog.Errorf("error syncing TrackPod %q, retrying: %v", key, err)
+		c.wq.AddRateLimited(key)
+		return
+	}
+
+	c.wq.Forget(key)
+	utilruntime.HandleError(fmt.Errorf("dropping TrackPod %q out of the queue after %d retries: %v", key, c.maxRetries, err))
+}
+
+// reconcile brings the TrackPod of key to the desired state. Returns
+// whether the TrackPod is still progressing & has to be checked again.
+func (c *Controller) reconcile(key string) (bool, error) {
 	ns, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
-		klog.Errorf("error while splitting key into namespace & name: %s", err.Error())
-		return false
+		// invalid key, retrying won't help.
+		utilruntime.HandleError(fmt.Errorf("invalid resource key %q: %v", key, err))
+		return false, nil
 	}
 
 	tpod, err := c.tpodlister.TrackPods(ns).Get(name)
 	if err != nil {
-		klog.Errorf("error %s, Getting the tpod resource from lister.", err.Error())
-		return false
+		if errors.IsNotFound(err) {
+			c.expectations.delete(key)
+			c.failedPodsBackoff.delete(key)
+			// TrackPod is deleted, its pods have already been drained by
+			// the finalizer.
+			klog.V(4).Infof("TrackPod %q has been deleted", key)
+			return false, nil
+		}
+		return false, err
+	}
+
+	// deleted TrackPod is only cleaned up, no pods are created for it.
+	if !tpod.DeletionTimestamp.IsZero() {
+		return c.finalize(tpod)
+	}
+	if !hasFinalizer(tpod) {
+		if err := c.addFinalizer(tpod); err != nil {
+			return false, fmt.Errorf("adding finalizer to TrackPod %s: %w", tpod.Name, err)
+		}
+		// update event of the finalizer gets it reconciled again.
+		return false, nil
+	}
+
+	// rollback is held as well while paused, until it's resumed.
+	if tpod.Spec.RollbackTo != nil && !tpod.Spec.Paused {
+		if err := c.rollback(tpod); err != nil {
+			return false, fmt.Errorf("rolling back TrackPod %s: %w", tpod.Name, err)
+		}
+		// update of the restored template gets it reconciled again.
+		return false, nil
 	}
 
 	// filter out if required pods are already available or not:
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			"controller": tpod.Name,
-		},
+	pList, err := c.claimPods(tpod)
+	if err != nil {
+		return false, fmt.Errorf("claiming the pods of TrackPod %s: %w", tpod.Name, err)
 	}
-	listOptions := metav1.ListOptions{
-		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
+
+	if err := c.syncRevisions(tpod, pList); err != nil {
+		return false, fmt.Errorf("syncing the revisions of TrackPod %s: %w", tpod.Name, err)
+	}
+	if err := c.syncDisruptionBudget(tpod); err != nil {
+		c.recorder.Event(tpod, corev1.EventTypeWarning, FailedSync, err.Error())
+		return false, fmt.Errorf("syncing the PodDisruptionBudget of TrackPod %s: %w", tpod.Name, err)
 	}
-	// TODO: Prefer using podLister to reduce the call to K8s API.
-	pList, _ := c.kubeClient.CoreV1().Pods(tpod.Namespace).List(context.TODO(), listOptions)
 
-	if err := c.syncHandler(tpod, pList); err != nil {
-		klog.Errorf("Error while syncing the current vs desired state for TrackPod %v: %v\n", tpod.Name, err.Error())
-		return false
+	// pods created/deleted by an earlier sync might not be in the cache
+	// yet, syncing now would create/delete them again. The pod events get
+	// the TrackPod synced once they show up.
+	var syncErr error
+	if tpod.Spec.Paused {
+		klog.V(4).Infof("TrackPod %q is paused, skipping the sync", key)
+	} else if c.expectations.satisfied(key) {
+		syncErr = c.syncHandler(tpod, pList)
+	} else {
+		klog.V(4).Infof("waiting for the pods of TrackPod %q to be observed, skipping the sync", key)
+	}
+
+	// status reflects the progress made so far, instead of waiting for the
+	// pods here. The pod events (or the requeue) get the TrackPod
+	// reconciled again until it meets the desired state.
+	statusErr := c.updateStatus(tpod, syncErr)
+
+	// evictions blocked by a disruption budget aren't a failure of the
+	// sync, they are retried once the budget (hopefully) allows them.
+	if blocked := evictionBlocked(syncErr); blocked != nil {
+		klog.V(4).Infof("%v, retrying TrackPod %q after %v", blocked, key, blocked.delay)
+		c.wq.AddAfter(key, blocked.delay)
+		if statusErr != nil {
+			return false, fmt.Errorf("updating status of TrackPod %s: %w", tpod.Name, statusErr)
+		}
+		return false, nil
 	}
 
-	// wait for pods to be ready
-	err = c.waitForPods(tpod, pList)
+	if syncErr != nil {
+		c.recorder.Event(tpod, corev1.EventTypeWarning, FailedSync, syncErr.Error())
+		return false, fmt.Errorf("syncing the current vs desired state for TrackPod %v: %w", tpod.Name, syncErr)
+	}
+	if statusErr != nil {
+		return false, fmt.Errorf("updating status of TrackPod %s: %w", tpod.Name, statusErr)
+	}
+
+	// paused TrackPod won't progress, until it's resumed.
+	if tpod.Spec.Paused {
+		return false, nil
+	}
+	progressing, err := c.checkProgress(tpod)
 	if err != nil {
-		klog.Errorf("error %s, waiting for pods to meet the expected state", err.Error())
+		klog.Errorf("error %s, checking the progress of TrackPod %s", err.Error(), tpod.Name)
 	}
 
-	// fmt.Println("Calling update status again!!")
-	err = c.updateStatus(tpod, tpod.Spec.Message, pList)
+	return progressing, nil
+}
+
+// lists the pods controlled by TrackPod from the pod cache, the pods are
+// adopted & released by claimPods.
+func (c *Controller) listPods(tpod *v1.TrackPod) ([]*corev1.Pod, error) {
+	pList, err := c.podLister.Pods(tpod.Namespace).List(labels.Everything())
 	if err != nil {
-		klog.Errorf("error %s updating status after waiting for Pods", err.Error())
+		return nil, err
 	}
 
-	return true
+	var owned []*corev1.Pod
+	for _, pod := range pList {
+		if metav1.IsControlledBy(pod, tpod) {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
 }
 
 // total number of 'Running' pods
-func (c *Controller) totalRunningPods(tpod *v1.TrackPod) int {
-	labelSelector := metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			"controller": tpod.Name,
-		},
-	}
-	listOptions := metav1.ListOptions{
-		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
+func (c *Controller) totalRunningPods(tpod *v1.TrackPod) (int, error) {
+	pList, err := c.listPods(tpod)
+	if err != nil {
+		return 0, err
 	}
-	// TODO: Prefer using podLister to reduce the call to K8s API.
-	pList, _ := c.kubeClient.CoreV1().Pods(tpod.Namespace).List(context.TODO(), listOptions)
 
 	runningPods := 0
-	for _, pod := range pList.Items {
+	for _, pod := range pList {
 		if pod.ObjectMeta.DeletionTimestamp.IsZero() && pod.Status.Phase == "Running" {
 			runningPods++
 		}
 	}
-	return runningPods
+	return runningPods, nil
+}
+
+// active pods are the ones neither being deleted nor terminated
+func isActive(pod *corev1.Pod) bool {
+	return pod.ObjectMeta.DeletionTimestamp.IsZero() &&
+		pod.Status.Phase != corev1.PodSucceeded &&
+		pod.Status.Phase != corev1.PodFailed
+}
+
+func activePods(pList []*corev1.Pod) []*corev1.Pod {
+	var active []*corev1.Pod
+	for _, pod := range pList {
+		if isActive(pod) {
+			active = append(active, pod)
+		}
+	}
+	return active
 }
 
 // syncHandler monitors the current state & if current != desired,
 // tries to meet the desired state.
-func (c *Controller) syncHandler(tpod *v1.TrackPod, pList *corev1.PodList) error {
-	var podCreate, podDelete bool
-	iterate := tpod.Spec.Count
-	deleteIterate := 0
-	runningPods := c.totalRunningPods(tpod)
-
-	if runningPods != tpod.Spec.Count || tpod.Spec.Message != tpod.Status.Message {
-		if tpod.Spec.Message != tpod.Status.Message {
-			klog.Warningf("the message of TrackPod %v resource has been modified, recreating the pods\n", tpod.Name)
-			podCreate = true
-			iterate = tpod.Spec.Count
-			if runningPods > 0 {
-				podDelete = true
-				deleteIterate = c.totalRunningPods(tpod)
+func (c *Controller) syncHandler(tpod *v1.TrackPod, pList []*corev1.Pod) error {
+	if len(tpod.Spec.Template.Spec.Containers) == 0 {
+		return fmt.Errorf("TrackPod %s doesn't define any container in spec.template", tpod.Name)
+	}
+
+	// pods created for TrackPod have to be selected by it, otherwise they'd
+	// be released as soon as they are created.
+	hash := templateHash(tpod)
+	selector, err := podSelector(tpod)
+	if err != nil {
+		return err
+	}
+	if !selector.Matches(labels.Set(newPod(tpod, hash).Labels)) {
+		return fmt.Errorf("selector of TrackPod %s doesn't match the labels of spec.template", tpod.Name)
+	}
+
+	// pods that are yet to be running are counted as well, as the
+	// reconcile doesn't wait for them to be running anymore.
+	newPods, oldPods := splitPods(activePods(pList), hash)
+
+	// failed pods are replaced, unless they keep failing & TrackPod is
+	// backing off.
+	inBackoff, err := c.handleFailedPods(tpod, pList)
+	if err != nil {
+		return err
+	}
+
+	// pods of an older template (or message) are replaced as per the
+	// strategy, one step per reconcile.
+	if len(oldPods) > 0 {
+		if len(newPods) == 0 {
+			klog.Warningf("the pod template of TrackPod %v resource has been modified, replacing the pods\n", tpod.Name)
+			c.recorder.Eventf(tpod, corev1.EventTypeNormal, MessageChanged, "Pod template or message has been modified, replacing %d pods (%s)", len(oldPods), strategyType(tpod))
+		}
+
+		if isRecreate(tpod) {
+			if err := c.removePods(tpod, oldPods); err != nil {
+				return err
 			}
-		} else {
-			klog.Warningf("detected mismatch of replica count for CR %v >> expected: %v & have: %v\n\n", tpod.Name, tpod.Spec.Count, runningPods)
-			if runningPods < tpod.Spec.Count {
-				podCreate = true
-				iterate = tpod.Spec.Count - runningPods
-				klog.Infof("Creating %v new pods\n", iterate)
-			} else if runningPods > tpod.Spec.Count {
-				podDelete = true
-				deleteIterate = runningPods - tpod.Spec.Count
-				klog.Infof("Deleting %v extra pods\n", deleteIterate)
+			// new pods are only created once the old ones are gone.
+			return nil
+		}
+
+		create, victims, err := rollingUpdate(tpod, newPods, oldPods)
+		if err != nil {
+			return err
+		}
+		// surge pods are still created while the evictions are blocked,
+		// the blocked ones are reported once they are.
+		removeErr := c.removePods(tpod, victims)
+		if removeErr != nil && evictionBlocked(removeErr) == nil {
+			return removeErr
+		}
+		if inBackoff {
+			create = 0
+		}
+		if err := c.createPods(tpod, hash, create); err != nil {
+			return err
+		}
+		return removeErr
+	}
+
+	// Recreate waits for the old pods to be gone, including the ones that
+	// are still terminating.
+	if isRecreate(tpod) {
+		for _, pod := range pList {
+			if pod.Labels[podTemplateHashLabel] != hash && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+				klog.V(4).Infof("waiting for the old pod %s of TrackPod %s to terminate", pod.Name, tpod.Name)
+				return nil
 			}
+		}
+	}
+
+	currentPods := len(newPods)
+	if currentPods == tpod.Spec.Count {
+		return nil
+	}
 
+	klog.Warningf("detected mismatch of replica count for CR %v >> expected: %v & have: %v\n\n", tpod.Name, tpod.Spec.Count, currentPods)
+	c.recorder.Eventf(tpod, corev1.EventTypeNormal, ScaleMismatch, "Expected %d pods, have %d", tpod.Spec.Count, currentPods)
+	if currentPods < tpod.Spec.Count {
+		if inBackoff {
+			return nil
 		}
+		klog.Infof("Creating %v new pods\n", tpod.Spec.Count-currentPods)
+		return c.createPods(tpod, hash, tpod.Spec.Count-currentPods)
 	}
 
-	// Delete extra pod
-	// TODO: Detect the manually created pod, and delete that specific pod.
-	if podDelete {
-		for i := 0; i < deleteIterate; i++ {
-			err := c.kubeClient.CoreV1().Pods(tpod.Namespace).Delete(context.TODO(), pList.Items[i].Name, metav1.DeleteOptions{})
-			if err != nil {
-				klog.Errorf("Pod deletion failed for CR %v\n", tpod.Name)
-				return err
+	// Delete extra pod, only the pods controlled by TrackPod are ever
+	// considered & the least valuable of them go first.
+	klog.Infof("Deleting %v extra pods\n", currentPods-tpod.Spec.Count)
+	return c.removePods(tpod, rankPods(tpod, newPods)[:currentPods-tpod.Spec.Count])
+}
+
+func strategyType(tpod *v1.TrackPod) v1.TrackPodStrategyType {
+	if isRecreate(tpod) {
+		return v1.RecreateTrackPodStrategyType
+	}
+	return v1.RollingUpdateTrackPodStrategyType
+}
+
+// deletes the given pods of TrackPod
+func (c *Controller) deletePods(tpod *v1.TrackPod, pods []*corev1.Pod) error {
+	if len(pods) == 0 {
+		return nil
+	}
+	key, err := cache.MetaNamespaceKeyFunc(tpod)
+	if err != nil {
+		return err
+	}
+	podKeys := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podKeys = append(podKeys, podKey(pod))
+	}
+	c.expectations.expectDeletions(key, podKeys)
+
+	for i, pod := range pods {
+		err := c.kubeClient.CoreV1().Pods(tpod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		if errors.IsNotFound(err) {
+			// pod that's already gone isn't waited for.
+			c.expectations.deletionObserved(key, podKey(pod))
+			continue
+		}
+		if err != nil {
+			// pods left out after a failure aren't waited for either.
+			for _, pod := range pods[i:] {
+				c.expectations.deletionObserved(key, podKey(pod))
 			}
+			klog.Errorf("Pod deletion failed for CR %v\n", tpod.Name)
+			c.recorder.Eventf(tpod, corev1.EventTypeWarning, FailedDelete, "Error deleting pod %s: %v", pod.Name, err)
+			return err
 		}
+		c.recorder.Eventf(tpod, corev1.EventTypeNormal, SuccessfulDelete, "Deleted pod: %s", pod.Name)
 	}
+	return nil
+}
 
-	// Creates pod
-	if podCreate {
-		for i := 0; i < iterate; i++ {
-			nPod, err := c.kubeClient.CoreV1().Pods(tpod.Namespace).Create(context.TODO(), newPod(tpod), metav1.CreateOptions{})
-			if err != nil {
-				if errors.IsAlreadyExists(err) {
-					// retry (might happen when the same named pod is created again)
-					iterate++
-				} else {
-					klog.Errorf("Pod creation failed for CR %v\n", tpod.Name)
-					return err
+// creates count new pods of the current pod template of TrackPod, in slow
+// start batches. A failing batch (e.g. on quota errors) stops the remaining
+// pods from being attempted, they are created by the following syncs.
+func (c *Controller) createPods(tpod *v1.TrackPod, hash string, count int) error {
+	if count <= 0 {
+		return nil
+	}
+	key, err := cache.MetaNamespaceKeyFunc(tpod)
+	if err != nil {
+		return err
+	}
+	c.expectations.expectCreations(key, count)
+
+	created, err := slowStartBatch(count, slowStartInitialBatchSize, func() error {
+		nPod, err := c.kubeClient.CoreV1().Pods(tpod.Namespace).Create(context.TODO(), newPod(tpod, hash), metav1.CreateOptions{})
+		if err != nil {
+			// failed pod won't ever be observed.
+			c.expectations.creationObserved(key)
+			klog.Errorf("Pod creation failed for CR %v\n", tpod.Name)
+			c.recorder.Eventf(tpod, corev1.EventTypeWarning, FailedCreate, "Error creating pod: %v", err)
+			return err
+		}
+		klog.Infof("Pod %v created successfully!\n", nPod.Name)
+		c.recorder.Eventf(tpod, corev1.EventTypeNormal, SuccessfulCreate, "Created pod: %s", nPod.Name)
+		return nil
+	})
+	// pods of the batches that weren't attempted won't be observed either.
+	for skipped := count - created; skipped > 0; skipped-- {
+		c.expectations.creationObserved(key)
+	}
+	return err
+}
+
+// slowStartBatch calls fn count times, in batches doubling in size from
+// initialBatchSize. Calls within a batch run in parallel, a batch with any
+// failure stops the ones after it. Returns the number of calls attempted
+// (successful or not) & the first error, if any.
+func slowStartBatch(count, initialBatchSize int, fn func() error) (int, error) {
+	remaining := count
+	attempted := 0
+	for batchSize := min(remaining, initialBatchSize); batchSize > 0; batchSize = min(2*batchSize, remaining) {
+		errCh := make(chan error, batchSize)
+		var wg sync.WaitGroup
+		wg.Add(batchSize)
+		for i := 0; i < batchSize; i++ {
+			go func() {
+				defer wg.Done()
+				if err := fn(); err != nil {
+					errCh <- err
 				}
-			}
-			if nPod.Name != "" {
-				klog.Infof("Pod %v created successfully!\n", nPod.Name)
-			}
+			}()
+		}
+		wg.Wait()
+		attempted += batchSize
+		remaining -= batchSize
+		if len(errCh) > 0 {
+			return attempted, <-errCh
 		}
 	}
+	return attempted, nil
+}
 
-	return nil
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
 
-// Creates the new pod with the specified template
-func newPod(tpod *v1.TrackPod) *corev1.Pod {
-	labels := map[string]string{
-		"controller": tpod.Name,
+// namespace/name key of the pod, used for the deletion expectations.
+func podKey(pod *corev1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+// Creates the new pod from the pod template of TrackPod
+func newPod(tpod *v1.TrackPod, hash string) *corev1.Pod {
+	template := tpod.Spec.Template.DeepCopy()
+
+	labels := map[string]string{}
+	for k, v := range template.Labels {
+		labels[k] = v
 	}
+	labels["controller"] = tpod.Name
+	labels[podTemplateHashLabel] = hash
+
+	// Message is optional, only inject it when it's been set.
+	if tpod.Spec.Message != "" {
+		for i := range template.Spec.Containers {
+			setEnv(&template.Spec.Containers[i], "MESSAGE", tpod.Spec.Message)
+		}
+	}
+
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Labels: labels,
+			Labels:      labels,
+			Annotations: template.Annotations,
 			// Name:      fmt.Sprintf(tpod.Name + "-" + strconv.Itoa(rand.Intn(10000000))),
 			GenerateName: fmt.Sprintf("%s-", tpod.Name),
 			Namespace:    tpod.Namespace,
@@ -282,67 +705,196 @@ func newPod(tpod *v1.TrackPod) *corev1.Pod {
 				*metav1.NewControllerRef(tpod, v1.SchemeGroupVersion.WithKind("TrackPod")),
 			},
 		},
-		Spec: corev1.PodSpec{
-			Containers: []corev1.Container{
-				{
-					Name:  "static-nginx",
-					Image: "nginx:latest",
-					Env: []corev1.EnvVar{
-						{
-							Name:  "MESSAGE",
-							Value: tpod.Spec.Message,
-						},
-					},
-					Command: []string{
-						"/bin/sh",
-					},
-					Args: []string{
-						"-c",
-						"while true; do echo '$(MESSAGE)'; sleep 100; done",
-					},
-				},
-			},
-		},
+		Spec: template.Spec,
 	}
 }
 
-// If the pod doesn't switch to a running state within 10 minutes, shall report.
-func (c *Controller) waitForPods(tpod *v1.TrackPod, pList *corev1.PodList) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-	defer cancel()
+// sets the env variable on the container, overriding the value if the
+// container already defines it.
+func setEnv(container *corev1.Container, name, value string) {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i].Value = value
+			container.Env[i].ValueFrom = nil
+			return
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+}
 
-	return poll.Wait(ctx, func(ctx context.Context) (bool, error) {
-		runningPods := c.totalRunningPods(tpod)
-		// fmt.Println("Inside waitforPods ???? totalrunningPods >>>> ", runningPods)
+// tells if the TrackPod is still progressing towards the desired state, i.e.
+// it's yet to have all the pods of the current template available. Pods
+// that aren't available within the progress deadline are logged, the
+// TrackPod itself is reported through its Progressing condition.
+func (c *Controller) checkProgress(tpod *v1.TrackPod) (bool, error) {
+	pList, err := c.listPods(tpod)
+	if err != nil {
+		return false, err
+	}
 
-		if runningPods == tpod.Spec.Count {
-			return true, nil
+	now := time.Now()
+	deadline := time.Duration(progressDeadlineSeconds(tpod)) * time.Second
+	availablePods := 0
+	newPods, oldPods := splitPods(activePods(pList), templateHash(tpod))
+	for _, pod := range newPods {
+		if isPodAvailable(pod, tpod.Spec.MinReadySeconds, now) {
+			availablePods++
+			continue
 		}
-		return false, nil
-	})
+		if now.Sub(pod.CreationTimestamp.Time) > deadline {
+			klog.Warningf("pod %s of TrackPod %s didn't become available within %v", pod.Name, tpod.Name, deadline)
+		}
+	}
+	return availablePods != tpod.Spec.Count || len(oldPods) > 0, nil
+}
+
+func (c *Controller) handleAdd(obj interface{}) {
+	c.enqueue(obj)
 }
 
-// Updates the status section of TrackPod
-func (c *Controller) updateStatus(tpod *v1.TrackPod, progress string, pList *corev1.PodList) error {
-	t, err := c.tpodClient.AjV1().TrackPods(tpod.Namespace).Get(context.Background(), tpod.Name, metav1.GetOptions{})
-	trunningPods := c.totalRunningPods(tpod)
+// deleted TrackPods are queued as well, the reconcile finds them missing
+// from the lister & treats it as a successful deletion.
+func (c *Controller) handleDel(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
-		return err
+		utilruntime.HandleError(err)
+		return
+	}
+	c.wq.Add(key)
+}
+
+// converts the object into a namespace/name key & puts it onto the queue.
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
 	}
+	c.wq.Add(key)
+}
 
-	t.Status.Count = trunningPods
-	t.Status.Message = progress
-	_, err = c.tpodClient.AjV1().TrackPods(tpod.Namespace).UpdateStatus(context.Background(), t, metav1.UpdateOptions{})
+// new pod is observed for the expectations of its TrackPod, before the
+// TrackPod is enqueued.
+func (c *Controller) addPod(obj interface{}) {
+	pod := obj.(*corev1.Pod)
+	// pod that's already marked for deletion (e.g. on a restart of the
+	// controller) won't be observed as added.
+	if !pod.DeletionTimestamp.IsZero() {
+		c.observeDeletion(pod)
+	} else if key, ok := ownerKey(pod); ok {
+		c.expectations.creationObserved(key)
+	}
+	c.handlePod(pod)
+}
 
-	return err
+func (c *Controller) deletePod(obj interface{}) {
+	pod, ok := podFromObj(obj)
+	if !ok {
+		return
+	}
+	c.observeDeletion(pod)
+	c.handlePod(pod)
 }
 
-func (c *Controller) handleAdd(obj interface{}) {
-	klog.Info("handleAdd is here!!!")
-	c.wq.Add(obj)
+// observes the deletion of pod, for the expectations of its TrackPod
+func (c *Controller) observeDeletion(pod *corev1.Pod) {
+	if key, ok := ownerKey(pod); ok {
+		c.expectations.deletionObserved(key, podKey(pod))
+	}
 }
 
-func (c *Controller) handleDel(obj interface{}) {
-	klog.Info("handleDel is here!!")
-	c.wq.Done(obj)
+// namespace/name key of the TrackPod controlling the pod
+func ownerKey(pod *corev1.Pod) (string, bool) {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil || ownerRef.Kind != "TrackPod" {
+		return "", false
+	}
+	return pod.Namespace + "/" + ownerRef.Name, true
+}
+
+// pod out of the informer's object, that might be a tombstone of the
+// deleted pod.
+func podFromObj(obj interface{}) (*corev1.Pod, bool) {
+	pod, ok := obj.(*corev1.Pod)
+	if ok {
+		return pod, true
+	}
+	tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+	if !ok {
+		klog.Errorf("error decoding object, invalid type %T", obj)
+		return nil, false
+	}
+	pod, ok = tombstone.Obj.(*corev1.Pod)
+	if !ok {
+		klog.Errorf("error decoding object tombstone, invalid type %T", tombstone.Obj)
+		return nil, false
+	}
+	return pod, true
+}
+
+// enqueues the TrackPod controlling the deleted PodDisruptionBudget, obj
+// might be a tombstone of it.
+func (c *Controller) deletePdb(obj interface{}) {
+	pdb, ok := obj.(*policyv1.PodDisruptionBudget)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("error decoding object, invalid type %T", obj)
+			return
+		}
+		pdb, ok = tombstone.Obj.(*policyv1.PodDisruptionBudget)
+		if !ok {
+			klog.Errorf("error decoding object tombstone, invalid type %T", tombstone.Obj)
+			return
+		}
+	}
+	c.enqueueController(pdb)
+}
+
+// enqueues the TrackPod that controls the pod, orphan pods get all the
+// TrackPods selecting them enqueued, for one of them to adopt it.
+func (c *Controller) handlePod(obj interface{}) {
+	pod, ok := podFromObj(obj)
+	if !ok {
+		return
+	}
+
+	if metav1.GetControllerOf(pod) == nil {
+		if pod.DeletionTimestamp.IsZero() {
+			c.enqueueSelecting(pod)
+		}
+		return
+	}
+	c.enqueueController(pod)
+}
+
+// enqueues the TrackPod controlling obj
+func (c *Controller) enqueueController(obj metav1.Object) {
+	ownerRef := metav1.GetControllerOf(obj)
+	if ownerRef == nil || ownerRef.Kind != "TrackPod" {
+		return
+	}
+	tpod, err := c.tpodlister.TrackPods(obj.GetNamespace()).Get(ownerRef.Name)
+	if err != nil || tpod.UID != ownerRef.UID {
+		return
+	}
+	c.enqueue(tpod)
+}
+
+// enqueues the TrackPods whose selector matches the orphan pod
+func (c *Controller) enqueueSelecting(pod *corev1.Pod) {
+	tpods, err := c.tpodlister.TrackPods(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, tpod := range tpods {
+		selector, err := podSelector(tpod)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			c.enqueue(tpod)
+		}
+	}
 }
@@ -0,0 +1,225 @@
+package trackpod
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	tFake "github.com/apoorvajagtap/trackPodCRD/pkg/client/clientset/versioned/fake"
+	tInfFac "github.com/apoorvajagtap/trackPodCRD/pkg/client/informers/externalversions"
+	tpodInf "github.com/apoorvajagtap/trackPodCRD/pkg/client/informers/externalversions/trackpod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+// fixture is a TrackPod controller on fake clientsets. The informers aren't
+// started, their caches only hold the objects the fixture is created with.
+type fixture struct {
+	c        *Controller
+	kube     *fake.Clientset
+	tpods    *tFake.Clientset
+	recorder *record.FakeRecorder
+	queue    *fakeQueue
+	clock    *testingclock.FakeClock
+	// events drained from the recorder, of reasons not asked for yet
+	pending []string
+}
+
+func newFixture(t *testing.T, objects ...runtime.Object) *fixture {
+	t.Helper()
+	var kubeObjects, tpodObjects []runtime.Object
+	for _, obj := range objects {
+		if _, ok := obj.(*v1.TrackPod); ok {
+			tpodObjects = append(tpodObjects, obj)
+		} else {
+			kubeObjects = append(kubeObjects, obj)
+		}
+	}
+
+	f := &fixture{
+		kube:     fake.NewSimpleClientset(kubeObjects...),
+		tpods:    tFake.NewSimpleClientset(tpodObjects...),
+		recorder: record.NewFakeRecorder(100),
+		clock:    testingclock.NewFakeClock(testNow),
+	}
+	tpodInformer := tpodInf.New(tInfFac.NewSharedInformerFactory(f.tpods, 0), metav1.NamespaceAll, nil).V1().TrackPods()
+	kubeInfoFact := informers.NewSharedInformerFactory(f.kube, 0)
+	podInformer := kubeInfoFact.Core().V1().Pods()
+	f.c = NewController(f.kube, f.tpods, tpodInformer, podInformer, kubeInfoFact.Apps().V1().ControllerRevisions(), kubeInfoFact.Policy().V1().PodDisruptionBudgets(), 5)
+
+	for _, obj := range objects {
+		indexer := podInformer.Informer().GetIndexer()
+		if _, ok := obj.(*v1.TrackPod); ok {
+			indexer = tpodInformer.Informer().GetIndexer()
+		}
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("adding %T to the cache: %v", obj, err)
+		}
+	}
+
+	f.c.recorder = f.recorder
+	f.queue = &fakeQueue{RateLimitingInterface: f.c.wq, after: map[string]time.Duration{}}
+	f.c.wq = f.queue
+	f.c.failedPodsBackoff.backoff = flowcontrol.NewFakeBackOff(failedPodsBackoffInitial, failedPodsBackoffMax, f.clock)
+	// actions of setting up the fixture aren't of interest.
+	f.kube.ClearActions()
+	f.tpods.ClearActions()
+	return f
+}
+
+// names of the objects the kube clientset was called to verb, with resource
+// (& subresource, as resource/subresource).
+func (f *fixture) actions(verb, resource string) []string {
+	names := []string{}
+	for _, action := range f.kube.Actions() {
+		res := action.GetResource().Resource
+		if sub := action.GetSubresource(); sub != "" {
+			res += "/" + sub
+		}
+		if action.GetVerb() != verb || res != resource {
+			continue
+		}
+		switch a := action.(type) {
+		case core.DeleteAction:
+			names = append(names, a.GetName())
+		case core.PatchAction:
+			names = append(names, a.GetName())
+		case core.CreateAction:
+			if obj, ok := a.GetObject().(metav1.Object); ok {
+				names = append(names, obj.GetName())
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// events recorded so far, of reason only. Events of other reasons are kept
+// for the later calls.
+func (f *fixture) events(reason string) []string {
+	for drained := false; !drained; {
+		select {
+		case event := <-f.recorder.Events:
+			f.pending = append(f.pending, event)
+		default:
+			drained = true
+		}
+	}
+	var events, rest []string
+	for _, event := range f.pending {
+		if strings.Contains(event, " "+reason+" ") {
+			events = append(events, event)
+		} else {
+			rest = append(rest, event)
+		}
+	}
+	f.pending = rest
+	return events
+}
+
+// TrackPod as it is once the controller has added its finalizer.
+func testTrackPod(name string, count int) *v1.TrackPod {
+	tpod := &v1.TrackPod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  metav1.NamespaceDefault,
+			UID:        types.UID(name + "-uid"),
+			Generation: 1,
+			Finalizers: []string{finalizerName},
+		},
+		Spec: v1.TrackPodSpec{Count: count},
+	}
+	tpod.Spec.Template.Spec.Containers = []corev1.Container{{Name: "main", Image: "busybox"}}
+	return tpod
+}
+
+// pod of the current template of TrackPod, as it's created by the
+// controller, with the name & status of pod.
+func podOf(tpod *v1.TrackPod, pod *corev1.Pod) *corev1.Pod {
+	p := newPod(tpod, templateHash(tpod))
+	p.GenerateName = ""
+	p.Name = pod.Name
+	p.UID = types.UID(pod.Name + "-uid")
+	p.CreationTimestamp = pod.CreationTimestamp
+	p.Spec.NodeName = pod.Spec.NodeName
+	p.Status = pod.Status
+	return p
+}
+
+// queue recording the delay a key is added after, instead of waiting for it.
+type fakeQueue struct {
+	workqueue.RateLimitingInterface
+	after map[string]time.Duration
+}
+
+func (q *fakeQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.after[item.(string)] = duration
+}
+
+func keyOf(t *testing.T, obj interface{}) string {
+	t.Helper()
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSlowStartBatch(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name         string
+		count        int
+		initialBatch int
+		// calls after the first successes fail, -1 never fails
+		successes     int
+		wantAttempted int
+		wantErr       bool
+	}{
+		{name: "nothing to do", count: 0, initialBatch: 1, successes: -1, wantAttempted: 0},
+		{name: "all succeed", count: 10, initialBatch: 1, successes: -1, wantAttempted: 10},
+		{name: "initial batch larger than count", count: 3, initialBatch: 5, successes: -1, wantAttempted: 3},
+		{name: "first call fails", count: 10, initialBatch: 1, successes: 0, wantAttempted: 1, wantErr: true},
+		// batches of 1, 2 & 4: the batch of 4 fails, the remaining 3 calls
+		// are never made.
+		{name: "third batch fails", count: 10, initialBatch: 1, successes: 3, wantAttempted: 7, wantErr: true},
+		{name: "last call fails", count: 10, initialBatch: 1, successes: 9, wantAttempted: 10, wantErr: true},
+		{name: "larger initial batch fails", count: 10, initialBatch: 4, successes: 5, wantAttempted: 10, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			attempted, err := slowStartBatch(tt.count, tt.initialBatch, func() error {
+				n := atomic.AddInt32(&calls, 1)
+				if tt.successes >= 0 && int(n) > tt.successes {
+					return errFailed
+				}
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("slowStartBatch() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, errFailed) {
+				t.Errorf("slowStartBatch() error = %v, want %v", err, errFailed)
+			}
+			if attempted != tt.wantAttempted || int(calls) != tt.wantAttempted {
+				t.Errorf("slowStartBatch() attempted %d (%d calls), want %d", attempted, calls, tt.wantAttempted)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package trackpod
+
+import (
+	"sort"
+	"strconv"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rankPods sorts the pods in the order they are to be deleted on scale down,
+// similar to the ReplicaSet controller:
+//  1. unscheduled before scheduled
+//  2. pending before unknown before running
+//  3. not ready before ready
+//  4. lower pod-deletion-cost before higher
+//  5. ready for a shorter time before longer
+//  6. more restarts before fewer
+//  7. newest (or oldest, as per scaleDownOrder) first
+func rankPods(tpod *v1.TrackPod, pods []*corev1.Pod) []*corev1.Pod {
+	ranked := make([]*corev1.Pod, len(pods))
+	copy(ranked, pods)
+	oldestFirst := tpod.Spec.ScaleDownOrder == v1.OldestScaleDownOrder
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if (a.Spec.NodeName == "") != (b.Spec.NodeName == "") {
+			return a.Spec.NodeName == ""
+		}
+		if phaseRank(a) != phaseRank(b) {
+			return phaseRank(a) < phaseRank(b)
+		}
+		if isPodReady(a) != isPodReady(b) {
+			return !isPodReady(a)
+		}
+		if deletionCost(a) != deletionCost(b) {
+			return deletionCost(a) < deletionCost(b)
+		}
+		if isPodReady(a) && isPodReady(b) {
+			readyA, readyB := readyTime(a), readyTime(b)
+			if !readyA.Equal(readyB) {
+				return readyB.Before(readyA)
+			}
+		}
+		if restarts(a) != restarts(b) {
+			return restarts(a) > restarts(b)
+		}
+		if oldestFirst {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return b.CreationTimestamp.Before(&a.CreationTimestamp)
+	})
+	return ranked
+}
+
+func phaseRank(pod *corev1.Pod) int {
+	switch pod.Status.Phase {
+	case corev1.PodPending:
+		return 0
+	case corev1.PodUnknown:
+		return 1
+	case corev1.PodRunning:
+		return 2
+	}
+	return 3
+}
+
+// deletion cost of the pod, as per its annotation. Pods without (or with an
+// invalid) annotation cost 0.
+func deletionCost(pod *corev1.Pod) int32 {
+	cost, err := strconv.ParseInt(pod.Annotations[v1.PodDeletionCostAnnotation], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(cost)
+}
+
+// time the pod has turned ready at
+func readyTime(pod *corev1.Pod) *metav1.Time {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return &cond.LastTransitionTime
+		}
+	}
+	return &metav1.Time{}
+}
+
+// highest restart count among the containers of pod
+func restarts(pod *corev1.Pod) int32 {
+	var max int32
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.RestartCount > max {
+			max = status.RestartCount
+		}
+	}
+	return max
+}
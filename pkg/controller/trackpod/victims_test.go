@@ -0,0 +1,136 @@
+package trackpod
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "github.com/apoorvajagtap/trackPodCRD/pkg/apis/trackpod/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// modifies the test pod with fn, & returns it
+func withPod(pod *corev1.Pod, fn func(*corev1.Pod)) *corev1.Pod {
+	fn(pod)
+	return pod
+}
+
+func unscheduled(pod *corev1.Pod) {
+	pod.Spec.NodeName = ""
+	pod.Status.Phase = corev1.PodPending
+}
+
+func phase(p corev1.PodPhase) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) { pod.Status.Phase = p }
+}
+
+func cost(c string) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Annotations = map[string]string{v1.PodDeletionCostAnnotation: c}
+	}
+}
+
+func restartCount(n int32) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "a", RestartCount: 1}, {Name: "b", RestartCount: n}}
+	}
+}
+
+func TestRankPods(t *testing.T) {
+	tests := []struct {
+		name  string
+		order v1.ScaleDownOrder
+		pods  []*corev1.Pod
+		want  []string
+	}{
+		{
+			name: "unscheduled first",
+			pods: []*corev1.Pod{testPod("scheduled", false, 0), withPod(testPod("unscheduled", false, 0), unscheduled)},
+			want: []string{"unscheduled", "scheduled"},
+		},
+		{
+			name: "pending, unknown then running",
+			pods: []*corev1.Pod{
+				testPod("running", false, 0),
+				withPod(testPod("unknown", false, 0), phase(corev1.PodUnknown)),
+				withPod(testPod("pending", false, 0), phase(corev1.PodPending)),
+			},
+			want: []string{"pending", "unknown", "running"},
+		},
+		{
+			name: "not ready first",
+			pods: []*corev1.Pod{testPod("ready", true, 0), testPod("not-ready", false, 0)},
+			want: []string{"not-ready", "ready"},
+		},
+		{
+			name: "lower deletion cost first",
+			pods: []*corev1.Pod{
+				withPod(testPod("high", true, 0), cost("100")),
+				withPod(testPod("negative", true, 0), cost("-5")),
+				testPod("none", true, 0),
+			},
+			want: []string{"negative", "none", "high"},
+		},
+		{
+			name: "invalid deletion cost is 0",
+			pods: []*corev1.Pod{withPod(testPod("one", true, 1), cost("1")), withPod(testPod("invalid", true, 0), cost("lots"))},
+			want: []string{"invalid", "one"},
+		},
+		{
+			name: "deletion cost goes before readiness time",
+			pods: []*corev1.Pod{withPod(testPod("costly-new", true, 30), cost("1")), testPod("old", true, 0)},
+			want: []string{"old", "costly-new"},
+		},
+		{
+			name: "ready for a shorter time first",
+			pods: []*corev1.Pod{testPod("long", true, 0), testPod("short", true, 30)},
+			want: []string{"short", "long"},
+		},
+		{
+			name: "more restarts first",
+			pods: []*corev1.Pod{withPod(testPod("few", false, 0), restartCount(2)), withPod(testPod("many", false, 0), restartCount(7))},
+			want: []string{"many", "few"},
+		},
+		{
+			name: "ties go to the newest by default",
+			pods: []*corev1.Pod{testPod("old", false, 0), testPod("new", false, 10), testPod("mid", false, 5)},
+			want: []string{"new", "mid", "old"},
+		},
+		{
+			name:  "ties go to the oldest with Oldest order",
+			order: v1.OldestScaleDownOrder,
+			pods:  []*corev1.Pod{testPod("new", false, 10), testPod("old", false, 0), testPod("mid", false, 5)},
+			want:  []string{"old", "mid", "new"},
+		},
+		{
+			name:  "order doesn't override the ranks",
+			order: v1.OldestScaleDownOrder,
+			pods:  []*corev1.Pod{testPod("old-ready", true, 0), testPod("new-not-ready", false, 10)},
+			want:  []string{"new-not-ready", "old-ready"},
+		},
+		{
+			name: "full ties keep their order",
+			pods: []*corev1.Pod{testPod("b", false, 0), testPod("a", false, 0), testPod("c", false, 0)},
+			want: []string{"b", "a", "c"},
+		},
+		{
+			name: "no pods",
+			pods: nil,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpod := &v1.TrackPod{Spec: v1.TrackPodSpec{ScaleDownOrder: tt.order}}
+			input := podNames(tt.pods)
+			got := podNames(rankPods(tpod, tt.pods))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rankPods() = %v, want %v", got, tt.want)
+			}
+			// pods passed in are left in their order.
+			if after := podNames(tt.pods); !reflect.DeepEqual(after, input) {
+				t.Errorf("rankPods() has reordered its input to %v", after)
+			}
+		})
+	}
+}